@@ -1,11 +1,12 @@
 module github.com/fredbi/go-patterns
 
-go 1.18
+go 1.23
 
 require (
 	github.com/Masterminds/squirrel v1.5.4
 	github.com/go-openapi/swag v0.22.3
 	github.com/jackc/pgx/v4 v4.18.1
+	github.com/jackc/pgx/v5 v5.5.0
 	github.com/jmoiron/sqlx v1.3.5
 	github.com/stretchr/testify v1.8.1
 	golang.org/x/exp v0.0.0-20231006140011-7918f672742d
@@ -22,7 +23,6 @@ require (
 	github.com/jackc/pgproto3/v2 v2.3.2 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
 	github.com/jackc/pgtype v1.14.0 // indirect
-	github.com/jackc/pgx/v5 v5.5.0 // indirect
 	github.com/jackc/puddle/v2 v2.2.1 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/lann/builder v0.0.0-20180802200727-47ae307949d0 // indirect
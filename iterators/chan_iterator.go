@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"iter"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
@@ -13,7 +14,8 @@ var _ StructIterator[dummy] = &ChanIterator[dummy]{}
 
 // ChanIterator is a channel-based iterator that may be used to run a collection of StructIterators in parallel.
 //
-// Notice that its asynchronous working does not make it suitable to collect ordered items.
+// Notice that its asynchronous working does not make it suitable to collect ordered items,
+// unless the WithChanOrdered option is used.
 //
 // The ChanIterator is goroutine-safe and may be iterated by several concurrent goroutines.
 //
@@ -22,28 +24,42 @@ var _ StructIterator[dummy] = &ChanIterator[dummy]{}
 //
 // Item() may return io.EOF is the iterator is done with producing records (e.g. some other consumer reached the end of the stream).
 //
+// Unlike other iterators wrapping StructIterators, the ChanIterator does not pass on item errors:
+// an error returned by the Item() method of an input iterator stops all workers, and is reported by Err() and Close().
+//
 // WithChanFanInBuffers may be used to pre-fetch from input iterators asynchronously.
 //
+// WithChanOrdered may be used to deliver all items from the first input iterator, then from the second etc,
+// while still fetching from all inputs in parallel.
+//
 // Methods Collect() and CollectPrt() can't be used by concurrent goroutines and are protected against such a misuse.
 type ChanIterator[T any] struct {
 	fanIn       chan T
 	workerGroup *errgroup.Group
 	ctx         context.Context
+	parentCtx   context.Context
+	cancel      context.CancelFunc
 	mx          sync.Mutex
 	done        chan struct{}
+	err         error
+	errMx       sync.Mutex
 
 	*chanIteratorOptions
 }
 
 // NewChanIterator builds a ChanIterator and starts the goroutines pumping items from the input iterators.
 //
-// All goroutines are terminated and input iterators closed if the context is cancelled.
+// All goroutines are terminated and input iterators closed if the context is cancelled,
+// or when the iterator is closed.
 func NewChanIterator[T any](ctx context.Context, iterators []StructIterator[T], opts ...ChanIteratorOption) *ChanIterator[T] {
 	var pendingWorkers sync.WaitGroup // rendez-vous to close the fan-in channel
-	workerGroup, groupCtx := errgroup.WithContext(ctx)
+	cancellableCtx, cancel := context.WithCancel(ctx)
+	workerGroup, groupCtx := errgroup.WithContext(cancellableCtx)
 
 	iter := &ChanIterator[T]{
 		ctx:                 groupCtx,
+		parentCtx:           ctx,
+		cancel:              cancel,
 		workerGroup:         workerGroup,
 		chanIteratorOptions: chanIteratorOptionsWithDefault(opts),
 		done:                make(chan struct{}),
@@ -55,15 +71,31 @@ func NewChanIterator[T any](ctx context.Context, iterators []StructIterator[T],
 
 	iter.fanIn = make(chan T, iter.fanInBuffers)
 
+	var outputs []chan T
+	if iter.ordered {
+		// each input is prefetched into its own buffer, then sequenced into the fan-in channel
+		outputs = make([]chan T, len(iterators))
+		for i := range outputs {
+			outputs[i] = make(chan T, iter.fanInBuffers)
+		}
+	}
+
 	for i := range iterators {
 		idx := i
 		pendingWorkers.Add(1)
 
-		workerGroup.Go(func() error {
+		iter.goWorker(func() error {
 			iterator := iterators[idx]
+			output := iter.fanIn
+			if iter.ordered {
+				output = outputs[idx]
+			}
 
 			defer func() {
 				_ = iterator.Close()
+				if iter.ordered {
+					close(output)
+				}
 				pendingWorkers.Done()
 			}()
 
@@ -76,7 +108,27 @@ func NewChanIterator[T any](ctx context.Context, iterators []StructIterator[T],
 				select {
 				case <-groupCtx.Done():
 					return groupCtx.Err()
-				case iter.fanIn <- item:
+				case output <- item:
+				}
+			}
+
+			return iteratorErr(iterator)
+		})
+	}
+
+	if iter.ordered {
+		pendingWorkers.Add(1)
+
+		iter.goWorker(func() error {
+			defer pendingWorkers.Done()
+
+			for _, input := range outputs {
+				for item := range input {
+					select {
+					case <-groupCtx.Done():
+						return groupCtx.Err()
+					case iter.fanIn <- item:
+					}
 				}
 			}
 
@@ -95,6 +147,38 @@ func NewChanIterator[T any](ctx context.Context, iterators []StructIterator[T],
 	return iter
 }
 
+// goWorker runs a worker in the group, recording the first error that interrupts the iteration.
+func (d *ChanIterator[T]) goWorker(worker func() error) {
+	d.workerGroup.Go(func() error {
+		err := worker()
+		if err == nil {
+			return nil
+		}
+
+		d.errMx.Lock()
+		defer d.errMx.Unlock()
+
+		if d.err == nil && !(errors.Is(err, context.Canceled) && d.parentCtx.Err() == nil) {
+			// workers interrupted by the failure of another worker, or by Close(), do not report
+			d.err = err
+		}
+
+		return err
+	})
+}
+
+// Err returns the first error returned by an input iterator, or the context error if the context is cancelled.
+func (d *ChanIterator[T]) Err() error {
+	d.errMx.Lock()
+	defer d.errMx.Unlock()
+
+	if d.err != nil {
+		return d.err
+	}
+
+	return d.parentCtx.Err()
+}
+
 func (d *ChanIterator[T]) Next() bool {
 	select {
 	case <-d.done:
@@ -121,8 +205,19 @@ func (d *ChanIterator[T]) Item() (T, error) {
 	}
 }
 
+// Close stops all pending workers, then waits for them to complete and relinquish their input iterators.
+//
+// It returns the first error reported by an input iterator, if any.
 func (d *ChanIterator[T]) Close() error {
-	return d.workerGroup.Wait()
+	d.cancel()
+
+	err := d.workerGroup.Wait()
+	if errors.Is(err, context.Canceled) && d.parentCtx.Err() == nil {
+		// workers have been interrupted by Close(), not by the caller's context
+		return nil
+	}
+
+	return err
 }
 
 func (d *ChanIterator[T]) Collect() ([]T, error) {
@@ -166,13 +261,20 @@ func (d *ChanIterator[T]) CollectPtr() ([]*T, error) {
 	return results, d.Close()
 }
 
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (d *ChanIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](d)
+}
+
 // preferErrorOverContext returns a specific error preferrably
 // to the generic "context cancelled" error, whenever available.
 func preferErrorOverContext(err1, err2 error) error {
 	isCancelled1 := errors.Is(err1, context.Canceled)
 	isCancelled2 := errors.Is(err2, context.Canceled)
 
-	if isCancelled1 && !isCancelled2 {
+	if isCancelled1 && err2 != nil && !isCancelled2 {
 		return err2
 	}
 
@@ -3,6 +3,7 @@ package iterators
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -55,6 +56,50 @@ func TestChanIterator(t *testing.T) {
 		require.Len(t, itemsPtr, 4)
 	})
 
+	t.Run("should Collect items in input order", func(t *testing.T) {
+		const (
+			inputs   = 5
+			itemsPer = 100
+		)
+		baseIterators := make([]StructIterator[dummyStruct], 0, inputs)
+		expected := make([]dummyStruct, 0, inputs*itemsPer)
+		for i := 0; i < inputs; i++ {
+			slice := make([]dummyStruct, 0, itemsPer)
+			for j := 0; j < itemsPer; j++ {
+				slice = append(slice, dummyStruct{A: i, B: strconv.Itoa(j)})
+			}
+			expected = append(expected, slice...)
+			baseIterators = append(baseIterators, NewSliceIterator[dummyStruct](slice))
+		}
+
+		iterator := NewChanIterator[dummyStruct](context.Background(), baseIterators,
+			WithChanOrdered(),
+			WithChanFanInBuffers(10),
+		)
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, expected, items)
+	})
+
+	t.Run("should CollectPtr items in input order (unbuffered)", func(t *testing.T) {
+		baseIterators := []StructIterator[dummyStruct]{
+			NewSliceIterator[dummyStruct]([]dummyStruct{{A: 1}, {A: 2}}),
+			NewSliceIterator[dummyStruct](nil),
+			NewSliceIterator[dummyStruct]([]dummyStruct{{A: 3}, {A: 4}}),
+		}
+
+		iterator := NewChanIterator[dummyStruct](context.Background(), baseIterators,
+			WithChanOrdered(),
+			WithChanFanInBuffers(0),
+		)
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 4)
+		for i, item := range items {
+			require.Equal(t, i+1, item.A)
+		}
+	})
+
 	t.Run("Items() should stop on cancelled context", func(t *testing.T) {
 		baseIterators := []StructIterator[dummyStruct]{
 			NewSliceIterator[dummyStruct](dummySlice()),
@@ -122,6 +167,34 @@ func TestChanIterator(t *testing.T) {
 		_, err := iterator.CollectPtr()
 		require.ErrorIs(t, err, errTest)
 	})
+
+	t.Run("Err should report the worker error", func(t *testing.T) {
+		baseIterator := NewSliceIterator[dummyStruct](dummySlice())
+		errorIterator := NewTransformIterator[dummyStruct, dummyStruct](context.Background(), baseIterator, errorer)
+
+		iterator := NewChanIterator[dummyStruct](context.Background(),
+			[]StructIterator[dummyStruct]{errorIterator},
+		)
+
+		for iterator.Next() {
+			_, _ = iterator.Item()
+		}
+		require.ErrorIs(t, iterator.Err(), errTest)
+		require.ErrorIs(t, iterator.Close(), errTest)
+	})
+
+	t.Run("Collect should error in ordered mode", func(t *testing.T) {
+		baseIterator := NewSliceIterator[dummyStruct](dummySlice())
+		errorIterator := NewTransformIterator[dummyStruct, dummyStruct](context.Background(), baseIterator, errorer)
+
+		iterator := NewChanIterator[dummyStruct](context.Background(),
+			[]StructIterator[dummyStruct]{NewSliceIterator[dummyStruct](dummySlice()), errorIterator},
+			WithChanOrdered(),
+		)
+
+		_, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+	})
 }
 
 func TestPreferErrorOverContext(t *testing.T) {
@@ -129,4 +202,5 @@ func TestPreferErrorOverContext(t *testing.T) {
 	require.ErrorIs(t, preferErrorOverContext(context.Canceled, errTest), errTest)
 	require.ErrorIs(t, preferErrorOverContext(errTest, context.Canceled), errTest)
 	require.ErrorIs(t, preferErrorOverContext(errTest, errors.New("another error")), errTest)
+	require.ErrorIs(t, preferErrorOverContext(context.Canceled, nil), context.Canceled)
 }
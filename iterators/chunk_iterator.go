@@ -0,0 +1,137 @@
+package iterators
+
+import (
+	"io"
+	"iter"
+
+	"github.com/fredbi/go-patterns/batchers"
+)
+
+var (
+	_ StructIterator[[]dummy]               = &ChunkIterator[dummy, []dummy]{}
+	_ StructIterator[batchers.Batch[dummy]] = &ChunkIterator[dummy, batchers.Batch[dummy]]{}
+)
+
+// ChunkIterator regroups the items of type T delivered by any iterator into chunks of type C,
+// a slice of T.
+//
+// Chunks contain at most size items. The last chunk may be smaller.
+//
+// An error returned by the Item() method of the source iterator is returned by Item() with an empty chunk,
+// after the items collected so far have been delivered as a partial chunk. The iteration then continues.
+//
+// Every chunk is a newly allocated slice, which may be retained safely by the consumer.
+//
+// Notice that the chunk iterator is not goroutine-safe and should not be iterated concurrently.
+type ChunkIterator[T any, C ~[]T] struct {
+	StructIterator[T]
+	size       int
+	current    C
+	err        error
+	pendingErr error
+	hasCurrent bool
+
+	*rowsIteratorOptions
+}
+
+// NewChunkIterator makes a StructIterator[[]T] delivering chunks of at most size items.
+//
+// A size lower than 1 is interpreted as 1.
+func NewChunkIterator[T any](iterator StructIterator[T], size int, opts ...RowsIteratorOption) *ChunkIterator[T, []T] {
+	return newChunkIterator[T, []T](iterator, size, opts)
+}
+
+// NewBatchIterator makes a StructIterator[batchers.Batch[T]] delivering chunks of at most size items.
+//
+// A size lower than 1 is interpreted as 1.
+func NewBatchIterator[T any](iterator StructIterator[T], size int, opts ...RowsIteratorOption) *ChunkIterator[T, batchers.Batch[T]] {
+	return newChunkIterator[T, batchers.Batch[T]](iterator, size, opts)
+}
+
+func newChunkIterator[T any, C ~[]T](iterator StructIterator[T], size int, opts []RowsIteratorOption) *ChunkIterator[T, C] {
+	if size < 1 {
+		size = 1
+	}
+
+	return &ChunkIterator[T, C]{
+		StructIterator:      iterator,
+		size:                size,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (ci *ChunkIterator[T, C]) Next() bool {
+	ci.current = nil
+	ci.hasCurrent = false
+	ci.err = nil
+
+	if ci.pendingErr != nil {
+		// an error occurred after a partial chunk has been delivered
+		ci.err = ci.pendingErr
+		ci.pendingErr = nil
+		ci.hasCurrent = true
+
+		return true
+	}
+
+	chunk := make(C, 0, ci.size)
+	for len(chunk) < ci.size && ci.StructIterator.Next() {
+		item, err := ci.StructIterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			if len(chunk) == 0 {
+				ci.err = err
+				ci.hasCurrent = true
+
+				return true
+			}
+
+			// deliver the partial chunk first
+			ci.pendingErr = err
+
+			break
+		}
+
+		chunk = append(chunk, item)
+	}
+
+	if len(chunk) == 0 {
+		return false
+	}
+
+	ci.current = chunk
+	ci.hasCurrent = true
+
+	return true
+}
+
+func (ci *ChunkIterator[T, C]) Item() (C, error) {
+	if !ci.hasCurrent {
+		return nil, io.EOF
+	}
+
+	return ci.current, ci.err
+}
+
+// Err returns the error reported by the source iterator, if any.
+func (ci *ChunkIterator[T, C]) Err() error {
+	return iteratorErr(ci.StructIterator)
+}
+
+func (ci *ChunkIterator[T, C]) Collect() ([]C, error) {
+	return collectAndClose[C](ci, ci.preallocatedItems)
+}
+
+func (ci *ChunkIterator[T, C]) CollectPtr() ([]*C, error) {
+	return collectPtrAndClose[C](ci, ci.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the chunks of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ci *ChunkIterator[T, C]) All() iter.Seq2[C, error] {
+	return Seq[C](ci)
+}
@@ -0,0 +1,136 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/fredbi/go-patterns/batchers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkIterator(t *testing.T) {
+	t.Run("should Collect chunks with a final partial chunk", func(t *testing.T) {
+		iterator := NewChunkIterator[int](NewSliceIterator(intSlice(7)), 3)
+
+		chunks, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, chunks)
+	})
+
+	t.Run("should CollectPtr exact chunks", func(t *testing.T) {
+		iterator := NewChunkIterator[int](NewSliceIterator(intSlice(6)), 2, WithRowsPreallocatedItems(10))
+
+		chunks, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, chunks, 3)
+		require.Equal(t, 10, cap(chunks))
+		require.Equal(t, []int{5, 6}, *chunks[2])
+	})
+
+	t.Run("should deliver batches", func(t *testing.T) {
+		iterator := NewBatchIterator[int](NewSliceIterator(intSlice(7)), 4)
+
+		require.True(t, iterator.Next())
+		batch, err := iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, batchers.Batch[int]{1, 2, 3, 4}, batch)
+		require.Equal(t, 4, batch.Len())
+
+		require.True(t, iterator.Next())
+		batch, err = iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, 3, batch.Len())
+
+		require.False(t, iterator.Next())
+		_, err = iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should default to chunks of 1 item", func(t *testing.T) {
+		iterator := NewChunkIterator[int](NewSliceIterator(intSlice(7)), 0)
+
+		chunks, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, chunks, 7)
+	})
+
+	t.Run("with empty input", func(t *testing.T) {
+		iterator := NewChunkIterator[int](NewSliceIterator[int](nil), 3)
+
+		chunks, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Empty(t, chunks)
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		iterator := NewChunkIterator[int](newEOFTerminated[int](NewSliceIterator(intSlice(7))), 3)
+
+		chunks, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, chunks)
+
+		t.Run("with a ChanIterator source", func(t *testing.T) {
+			for range 100 {
+				inputs := []StructIterator[int]{NewSliceIterator(intSlice(5)), NewSliceIterator(intSlice(5))}
+				iterator := NewChunkIterator[int](NewChanIterator[int](context.Background(), inputs), 3)
+
+				chunks, err := iterator.Collect()
+				require.NoError(t, err)
+
+				var count int
+				for _, chunk := range chunks {
+					count += len(chunk)
+				}
+				require.Equal(t, 10, count)
+			}
+		})
+	})
+
+	t.Run("should close the source", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(7)))
+		iterator := NewChunkIterator[int](tracker, 3)
+
+		require.True(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should deliver a partial chunk then the item error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in == 5 {
+				return 0, errTest
+			}
+
+			return in, nil
+		}
+		errorIterator := NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(7)), errorer)
+		iterator := NewChunkIterator[int](errorIterator, 3)
+
+		chunks, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, [][]int{{1, 2, 3}, {4}}, chunks)
+
+		t.Run("should continue after the item error", func(t *testing.T) {
+			errorIterator := NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(7)), errorer)
+			iterator := NewChunkIterator[int](errorIterator, 3)
+
+			chunks, err := CollectWithPolicy[[]int](iterator, SkipErrors())
+			require.ErrorIs(t, err, errTest)
+			require.Equal(t, [][]int{{1, 2, 3}, {4}, {6, 7}}, chunks)
+		})
+	})
+}
+
+// intSlice returns the integers from 1 to n.
+func intSlice(n int) []int {
+	s := make([]int, 0, n)
+	for i := 1; i <= n; i++ {
+		s = append(s, i)
+	}
+
+	return s
+}
@@ -0,0 +1,268 @@
+package iterators
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fredbi/go-patterns/sorters"
+)
+
+type (
+	// ErrorPolicy tells how to handle errors returned by Item() when collecting items.
+	//
+	// See FailFast, SkipErrors and MaxErrors.
+	ErrorPolicy struct {
+		maxErrors int
+	}
+
+	// ItemError is an error returned by Item() while collecting items.
+	//
+	// Index is the IteratorContext.Iterated of the item when the collected iterator exposes an IteratorContext
+	// (TransformIterator, FilterIterator, FlatMapIterator, TakeWhile and DropWhile iterators),
+	// i.e. the position of the item in the source iterator, starting at 1.
+	//
+	// Otherwise, Index is the position of the item among the items delivered by the collected iterator.
+	ItemError struct {
+		Index int
+		Err   error
+	}
+
+	// ItemErrors aggregates all the errors recorded while collecting items.
+	ItemErrors []ItemError
+
+	// iteratedCounter is implemented by the iterators exposing an IteratorContext.
+	//
+	// It tells the IteratorContext.Iterated of the current item.
+	iteratedCounter interface {
+		iteratedCount() int
+	}
+)
+
+var (
+	_ iteratedCounter = &TransformIterator[dummy, dummy]{}
+	_ iteratedCounter = &FilterIterator[dummy]{}
+	_ iteratedCounter = &FlatMapIterator[dummy, dummy]{}
+	_ iteratedCounter = &TakeWhileIterator[dummy]{}
+	_ iteratedCounter = &DropWhileIterator[dummy]{}
+)
+
+// FailFast interrupts the collection at the first error. This is the behavior of Collect().
+func FailFast() ErrorPolicy {
+	return ErrorPolicy{maxErrors: 1}
+}
+
+// SkipErrors skips all the items in error and records the errors.
+func SkipErrors() ErrorPolicy {
+	return ErrorPolicy{}
+}
+
+// MaxErrors skips the items in error and records the errors, until n errors are recorded.
+// The collection is then interrupted.
+//
+// A value lower than 1 is interpreted as 1.
+func MaxErrors(n int) ErrorPolicy {
+	return ErrorPolicy{maxErrors: max(n, 1)}
+}
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+func (e ItemErrors) Error() string {
+	causes := make([]string, 0, len(e))
+	for _, err := range e {
+		causes = append(causes, err.Error())
+	}
+
+	return fmt.Sprintf("%d item errors: %s", len(e), strings.Join(causes, "; "))
+}
+
+// Unwrap allows errors.Is and errors.As to match any of the recorded errors.
+func (e ItemErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// CollectWithPolicy returns all items from an iterator in one slice, then closes the iterator.
+//
+// Unlike Collect(), the items in error may be skipped, depending on the ErrorPolicy.
+// The recorded errors are returned as ItemErrors, together with the items collected successfully.
+//
+// An error that interrupts the iteration, or an error returned by Close(), is returned as well.
+//
+// The only RowsIteratorOption supported is WithRowsPreallocatedItems.
+func CollectWithPolicy[T any](iterator StructIterator[T], policy ErrorPolicy, opts ...RowsIteratorOption) ([]T, error) {
+	return collectWithPolicy(iterator, policy, opts, func(item T) T { return item })
+}
+
+// CollectPtrWithPolicy returns all items from an iterator in one slice of pointers, then closes the iterator.
+//
+// The ErrorPolicy applies like with CollectWithPolicy.
+func CollectPtrWithPolicy[T any](iterator StructIterator[T], policy ErrorPolicy, opts ...RowsIteratorOption) ([]*T, error) {
+	return collectWithPolicy(iterator, policy, opts, func(item T) *T { return &item })
+}
+
+func collectWithPolicy[T, E any](iterator StructIterator[T], policy ErrorPolicy, opts []RowsIteratorOption, wrap func(T) E) ([]E, error) {
+	options := rowsIteratorOptionsWithDefault(opts)
+	collection := make([]E, 0, options.preallocatedItems)
+	var itemErrs ItemErrors
+
+	for delivered := 1; iterator.Next(); delivered++ {
+		item, err := iterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			itemErrs = append(itemErrs, ItemError{Index: itemIndex(iterator, delivered), Err: err})
+
+			if policy.maxErrors > 0 && len(itemErrs) >= policy.maxErrors {
+				break
+			}
+
+			continue
+		}
+
+		collection = append(collection, wrap(item))
+	}
+
+	err := iteratorErr(iterator)
+	if closeErr := iterator.Close(); err == nil {
+		err = closeErr
+	}
+
+	if len(itemErrs) == 0 {
+		return collection, err
+	}
+
+	if err != nil {
+		return collection, errors.Join(err, itemErrs)
+	}
+
+	return collection, itemErrs
+}
+
+// itemIndex returns the IteratorContext.Iterated of the current item when the iterator exposes one,
+// or the position of the item among the delivered items.
+func itemIndex(iterator Iterator, delivered int) int {
+	if counter, ok := iterator.(iteratedCounter); ok {
+		return counter.iteratedCount()
+	}
+
+	return delivered
+}
+
+// isEndOfStream tells if an error returned by Item() marks the end of the stream rather than an item error,
+// like the io.EOF returned by a ChanIterator once all its inputs are drained.
+func isEndOfStream(err error) bool {
+	return errors.Is(err, io.EOF)
+}
+
+// CollectInto calls fn for every item of an iterator, then closes the iterator.
+//
+// Items are processed one at a time, as they are iterated, so they are never all held in memory.
+//
+// The iteration stops at the first error, either returned by Item(), by fn or reported by Err().
+// An io.EOF returned by Item() is interpreted as the end of the stream (e.g. with a ChanIterator).
+func CollectInto[T any](iterator StructIterator[T], fn func(T) error) error {
+	for iterator.Next() {
+		item, err := iterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			_ = iterator.Close()
+
+			return err
+		}
+
+		if err = fn(item); err != nil {
+			_ = iterator.Close()
+
+			return err
+		}
+	}
+
+	if err := iteratorErr(iterator); err != nil {
+		_ = iterator.Close()
+
+		return err
+	}
+
+	return iterator.Close()
+}
+
+// CollectMap returns all items from an iterator in a map indexed by key, then closes the iterator.
+//
+// When several items share the same key, the last one is retained.
+//
+// The only RowsIteratorOption supported is WithRowsPreallocatedItems.
+func CollectMap[T any, K comparable](iterator StructIterator[T], key func(T) K, opts ...RowsIteratorOption) (map[K]T, error) {
+	options := rowsIteratorOptionsWithDefault(opts)
+	collection := make(map[K]T, options.preallocatedItems)
+
+	err := CollectInto(iterator, func(item T) error {
+		collection[key(item)] = item
+
+		return nil
+	})
+
+	return collection, err
+}
+
+// CollectGroups returns all items from an iterator, grouped by key, then closes the iterator.
+//
+// Within a group, items are retained in the order of the iteration.
+// Unlike the GroupByIterator, the iterator doesn't need to be sorted by key.
+//
+// The only RowsIteratorOption supported is WithRowsPreallocatedItems.
+func CollectGroups[T any, K comparable](iterator StructIterator[T], key func(T) K, opts ...RowsIteratorOption) (map[K][]T, error) {
+	options := rowsIteratorOptionsWithDefault(opts)
+	collection := make(map[K][]T, options.preallocatedItems)
+
+	err := CollectInto(iterator, func(item T) error {
+		k := key(item)
+		collection[k] = append(collection[k], item)
+
+		return nil
+	})
+
+	return collection, err
+}
+
+// CollectSorted returns all items from an iterator in one slice sorted according to a comparison,
+// then closes the iterator.
+//
+// Several sorting criteria may be combined with sorters.CompoundCriteria.
+// Items are not sorted if an error occurs.
+//
+// The only RowsIteratorOption supported is WithRowsPreallocatedItems.
+func CollectSorted[T any](iterator StructIterator[T], comparison sorters.Comparison[T], opts ...RowsIteratorOption) ([]T, error) {
+	options := rowsIteratorOptionsWithDefault(opts)
+	collection := make([]T, 0, options.preallocatedItems)
+
+	err := CollectInto(iterator, func(item T) error {
+		collection = append(collection, item)
+
+		return nil
+	})
+	if err != nil {
+		return collection, err
+	}
+
+	sorter := sorters.NewMulti(collection, comparison)
+	sorter.Sort()
+
+	return sorter.Collection(), nil
+}
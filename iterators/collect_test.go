@@ -0,0 +1,246 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/fredbi/go-patterns/sorters"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectWithPolicy(t *testing.T) {
+	errTest := errors.New("test error")
+	// items 2, 4 and 6 are in error
+	evenErrors := func() StructIterator[int] {
+		return NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(6)), func(_ context.Context, in int) (int, error) {
+			if in%2 == 0 {
+				return 0, errTest
+			}
+
+			return in, nil
+		})
+	}
+
+	t.Run("should fail fast", func(t *testing.T) {
+		items, err := CollectWithPolicy(evenErrors(), FailFast())
+		require.Equal(t, []int{1}, items)
+		require.ErrorIs(t, err, errTest)
+
+		var itemErrs ItemErrors
+		require.ErrorAs(t, err, &itemErrs)
+		require.Len(t, itemErrs, 1)
+		require.Equal(t, 2, itemErrs[0].Index)
+	})
+
+	t.Run("should skip and record all errors", func(t *testing.T) {
+		tracker := newCloseTracker[int](evenErrors())
+		items, err := CollectWithPolicy[int](tracker, SkipErrors(), WithRowsPreallocatedItems(10))
+		require.Equal(t, []int{1, 3, 5}, items)
+		require.Equal(t, 10, cap(items))
+		require.ErrorIs(t, err, errTest)
+		require.EqualError(t, err, "3 item errors: item 2: test error; item 4: test error; item 6: test error")
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should stop after max errors", func(t *testing.T) {
+		items, err := CollectPtrWithPolicy(evenErrors(), MaxErrors(2))
+		require.Len(t, items, 2)
+		require.Equal(t, 3, *items[1])
+
+		var itemErrs ItemErrors
+		require.ErrorAs(t, err, &itemErrs)
+		require.Len(t, itemErrs, 2)
+		require.Equal(t, 4, itemErrs[1].Index)
+	})
+
+	t.Run("should index errors with IteratorContext.Iterated", func(t *testing.T) {
+		// item 1 is skipped: item 4 is the third delivered item, at position 4 in the source
+		predicate := func(_ context.Context, in int) (bool, error) {
+			if in == 4 {
+				return false, errTest
+			}
+
+			return in > 1, nil
+		}
+		iterator := NewFilterIterator[int](context.Background(), NewSliceIterator(intSlice(6)), predicate)
+
+		items, err := CollectWithPolicy[int](iterator, SkipErrors())
+		require.Equal(t, []int{2, 3, 5, 6}, items)
+
+		var itemErrs ItemErrors
+		require.ErrorAs(t, err, &itemErrs)
+		require.Len(t, itemErrs, 1)
+		require.Equal(t, 4, itemErrs[0].Index)
+
+		t.Run("with parallel workers", func(t *testing.T) {
+			iterator := NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(6)), func(_ context.Context, in int) (int, error) {
+				if in%2 == 0 {
+					return 0, errTest
+				}
+
+				return in, nil
+			}, WithTransformWorkers(3))
+
+			_, err := CollectWithPolicy[int](iterator, SkipErrors())
+
+			var itemErrs ItemErrors
+			require.ErrorAs(t, err, &itemErrs)
+			indexes := make([]int, 0, len(itemErrs))
+			for _, itemErr := range itemErrs {
+				indexes = append(indexes, itemErr.Index)
+			}
+			require.ElementsMatch(t, []int{2, 4, 6}, indexes)
+		})
+	})
+
+	t.Run("should end on io.EOF", func(t *testing.T) {
+		items, err := CollectWithPolicy[int](newEOFTerminated[int](evenErrors()), SkipErrors())
+		require.Equal(t, []int{1, 3, 5}, items)
+
+		var itemErrs ItemErrors
+		require.ErrorAs(t, err, &itemErrs)
+		require.Len(t, itemErrs, 3)
+		require.NotErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("should not error without item errors", func(t *testing.T) {
+		items, err := CollectPtrWithPolicy[int](NewSliceIterator(intSlice(6)), SkipErrors())
+		require.NoError(t, err)
+		require.Len(t, items, 6)
+	})
+
+	t.Run("should report the error that interrupted the iteration", func(t *testing.T) {
+		errCursor := errors.New("cursor error")
+		rows := newFakeRows([]string{"id"}, []any{1}, []any{"x"})
+		rows.err = errCursor
+
+		items, err := CollectWithPolicy[int](NewScanIterator[*fakeRows, int](rows), SkipErrors())
+		require.Equal(t, []int{1}, items)
+		require.ErrorIs(t, err, errCursor)
+
+		var itemErrs ItemErrors
+		require.ErrorAs(t, err, &itemErrs)
+		require.Len(t, itemErrs, 1)
+		require.Equal(t, 2, itemErrs[0].Index)
+		require.True(t, rows.closed)
+	})
+}
+
+func TestCollectors(t *testing.T) {
+	errTest := errors.New("test error")
+	items := func() []dummyStruct {
+		return []dummyStruct{{A: 3, B: "x"}, {A: 1, B: "y"}, {A: 2, B: "x"}, {A: 1, B: "z"}}
+	}
+	byA := func(in dummyStruct) int {
+		return in.A
+	}
+	compareA := func(a, b dummyStruct) int {
+		return sorters.OrderedComparator[int]()(a.A, b.A)
+	}
+	compareB := func(a, b dummyStruct) int {
+		return sorters.StringsComparator()(a.B, b.B)
+	}
+
+	t.Run("CollectInto should stream items", func(t *testing.T) {
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(items()))
+		var count int
+
+		err := CollectInto[dummyStruct](tracker, func(_ dummyStruct) error {
+			count++
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("CollectInto should stop on callback error", func(t *testing.T) {
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(items()))
+		var count int
+
+		err := CollectInto[dummyStruct](tracker, func(_ dummyStruct) error {
+			count++
+			if count == 2 {
+				return errTest
+			}
+
+			return nil
+		})
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, 2, count)
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("CollectInto should end on io.EOF", func(t *testing.T) {
+		var count int
+
+		err := CollectInto[dummyStruct](newEOFTerminated[dummyStruct](NewSliceIterator(items())), func(_ dummyStruct) error {
+			count++
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+	})
+
+	t.Run("CollectMap should end on io.EOF with a ChanIterator", func(t *testing.T) {
+		for range 20 {
+			inputs := []StructIterator[dummyStruct]{NewSliceIterator(items()[:2]), NewSliceIterator(items()[2:])}
+
+			collection, err := CollectMap[dummyStruct](NewChanIterator[dummyStruct](context.Background(), inputs), byA)
+			require.NoError(t, err)
+			require.Len(t, collection, 3)
+		}
+	})
+
+	t.Run("CollectMap should index items by key", func(t *testing.T) {
+		collection, err := CollectMap[dummyStruct](NewSliceIterator(items()), byA, WithRowsPreallocatedItems(4))
+		require.NoError(t, err)
+		require.Equal(t, map[int]dummyStruct{
+			1: {A: 1, B: "z"},
+			2: {A: 2, B: "x"},
+			3: {A: 3, B: "x"},
+		}, collection)
+	})
+
+	t.Run("CollectGroups should group items by key", func(t *testing.T) {
+		byB := func(in dummyStruct) string {
+			return in.B
+		}
+
+		groups, err := CollectGroups[dummyStruct](NewSliceIterator(items()), byB)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]dummyStruct{
+			"x": {{A: 3, B: "x"}, {A: 2, B: "x"}},
+			"y": {{A: 1, B: "y"}},
+			"z": {{A: 1, B: "z"}},
+		}, groups)
+	})
+
+	t.Run("CollectSorted should sort items", func(t *testing.T) {
+		sorted, err := CollectSorted[dummyStruct](NewSliceIterator(items()), sorters.CompoundCriteria(compareA, sorters.Reverse(compareB)),
+			WithRowsPreallocatedItems(10),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 10, cap(sorted))
+		require.Equal(t, []dummyStruct{{A: 1, B: "z"}, {A: 1, B: "y"}, {A: 2, B: "x"}, {A: 3, B: "x"}}, sorted)
+	})
+
+	t.Run("CollectSorted should report the iteration error", func(t *testing.T) {
+		errorer := func(_ context.Context, in dummyStruct) (dummyStruct, error) {
+			if in.A == 2 {
+				return dummyStruct{}, errTest
+			}
+
+			return in, nil
+		}
+		source := NewTransformIterator[dummyStruct, dummyStruct](context.Background(), NewSliceIterator(items()), errorer)
+
+		sorted, err := CollectSorted[dummyStruct](source, compareA)
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, sorted, 2)
+	})
+}
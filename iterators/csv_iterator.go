@@ -0,0 +1,318 @@
+package iterators
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+)
+
+var _ StructIterator[dummy] = &CSVIterator[dummy]{}
+
+// CSVIterator decodes items of type T from a CSV stream with a header line.
+//
+// Header columns are mapped to the fields of T using "csv" struct tags, or "db" struct tags when no "csv" tag
+// is found, so the same types may be used with a RowsIterator. Like with sqlx, fields without tags are mapped
+// to their lower-cased name.
+//
+// Cells are converted to strings, booleans, numbers, time.Time (see WithCSVTimeLayout), or any type implementing
+// sql.Scanner or encoding.TextUnmarshaler. An empty cell is converted to a nil pointer, or the zero value.
+//
+// If T is not a struct, or a pointer to a struct, records are expected to have a single column,
+// which is converted directly into T. An interface type such as any receives the cell as a string.
+//
+// A record that cannot be converted is reported by Item(), with its row and column, and the iteration goes on.
+// An error when reading the header or the stream interrupts the iteration, and is reported by Err().
+//
+// Notice that the CSV iterator is not goroutine-safe and should not be iterated concurrently.
+type CSVIterator[T any] struct {
+	reader   *csv.Reader
+	header   []string
+	indexes  [][]int
+	record   []string
+	line     int
+	itemErr  error
+	err      error
+	started  bool
+	done     bool
+	isScalar bool
+
+	*csvOptions
+}
+
+// NewCSVIterator makes a CSVIterator[T] producing items of type T from a reader.
+//
+// The reader is not closed by the iterator.
+func NewCSVIterator[T any](reader io.Reader, opts ...CSVOption) *CSVIterator[T] {
+	options := csvOptionsWithDefault(opts)
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = options.comma
+
+	return &CSVIterator[T]{
+		reader:     csvReader,
+		isScalar:   isCSVValue(reflect.TypeFor[T]()),
+		csvOptions: options,
+	}
+}
+
+// Header returns the columns of the CSV stream, once the first call to Next() has been made.
+func (ci *CSVIterator[T]) Header() []string {
+	return ci.header
+}
+
+func (ci *CSVIterator[T]) Next() bool {
+	ci.record = nil
+	ci.itemErr = nil
+
+	if ci.done {
+		return false
+	}
+
+	if !ci.started {
+		ci.started = true
+
+		if err := ci.readHeader(); err != nil {
+			return ci.stop(err)
+		}
+	}
+
+	record, err := ci.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			return ci.stop(err)
+		}
+
+		// a malformed record is reported by Item()
+		ci.itemErr = err
+		ci.line = parseErr.StartLine
+
+		return true
+	}
+
+	ci.line, _ = ci.reader.FieldPos(0)
+	ci.record = record
+
+	return true
+}
+
+func (ci *CSVIterator[T]) readHeader() error {
+	header, err := ci.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return fmt.Errorf("cannot read CSV header: %w", err)
+	}
+	ci.header = header
+
+	if ci.isScalar {
+		if len(header) != 1 {
+			var data T
+
+			return fmt.Errorf("expected a single column to convert into %T, but got %d", data, len(header))
+		}
+
+		return nil
+	}
+
+	t := csvStructType(reflect.TypeFor[T]())
+	indexes, err := fieldPlanFor(t, "csv", "db").indexes(t, header)
+	if err != nil {
+		return fmt.Errorf("cannot map CSV columns: %w", err)
+	}
+	ci.indexes = indexes
+
+	return nil
+}
+
+func (ci *CSVIterator[T]) stop(err error) bool {
+	ci.done = true
+
+	if !errors.Is(err, io.EOF) {
+		ci.err = err
+	}
+
+	return false
+}
+
+func (ci *CSVIterator[T]) Item() (T, error) {
+	var data T
+
+	if ci.itemErr != nil {
+		return data, ci.itemErr
+	}
+
+	if ci.record == nil {
+		return data, io.EOF
+	}
+
+	target := reflect.ValueOf(&data).Elem()
+	if ci.isScalar {
+		if err := parseCSVValue(target, ci.record[0], ci.timeLayout); err != nil {
+			return data, ci.cellError(0, err)
+		}
+
+		return data, nil
+	}
+
+	if target.Kind() == reflect.Pointer {
+		ptr := reflect.New(target.Type().Elem())
+		target.Set(ptr)
+		target = ptr.Elem()
+	}
+
+	for i, path := range ci.indexes {
+		if err := parseCSVValue(fieldByIndex(target, path), ci.record[i], ci.timeLayout); err != nil {
+			return data, ci.cellError(i, err)
+		}
+	}
+
+	return data, nil
+}
+
+func (ci *CSVIterator[T]) cellError(column int, err error) error {
+	return fmt.Errorf("row %d, column %d (%s): %w", ci.line, column+1, ci.header[column], err)
+}
+
+// Err returns the error that interrupted the reading of the stream, if any.
+func (ci *CSVIterator[T]) Err() error {
+	return ci.err
+}
+
+// Close stops the iteration. The underlying reader is not closed.
+func (ci *CSVIterator[T]) Close() error {
+	ci.done = true
+
+	return nil
+}
+
+func (ci *CSVIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](ci, ci.preallocatedItems)
+}
+
+func (ci *CSVIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](ci, ci.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ci *CSVIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](ci)
+}
+
+// WriteCSV writes all items from an iterator to a writer as CSV with a header line, then closes the iterator.
+//
+// Columns are named after the fields of T, like with the CSVIterator. If T is not a struct, or a pointer to a struct,
+// a single column named "value" is written. A nil pointer is written as a record of empty cells.
+//
+// Items are written one at a time, as they are iterated, so they are never all held in memory.
+//
+// The writing stops at the first error, either from the iterator or from the writer.
+// It returns the number of items written.
+func WriteCSV[T any](writer io.Writer, iterator StructIterator[T], opts ...CSVOption) (int, error) {
+	options := csvOptionsWithDefault(opts)
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = options.comma
+
+	t := reflect.TypeFor[T]()
+	isScalar := isCSVValue(t)
+
+	header := []string{"value"}
+	var plan *fieldPlan
+	if !isScalar {
+		plan = fieldPlanFor(csvStructType(t), "csv", "db")
+		header = plan.columns
+	}
+
+	written, err := writeCSV(csvWriter, iterator, header, func(item T) ([]string, error) {
+		value := reflect.ValueOf(item)
+		if isScalar {
+			cell, err := formatCSVValue(value, options.timeLayout)
+
+			return []string{cell}, err
+		}
+
+		record := make([]string, len(header))
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return record, nil
+			}
+			value = value.Elem()
+		}
+
+		for i, column := range header {
+			field, ok := fieldByIndexNoAlloc(value, plan.fields[column])
+			if !ok {
+				continue
+			}
+
+			cell, err := formatCSVValue(field, options.timeLayout)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", column, err)
+			}
+			record[i] = cell
+		}
+
+		return record, nil
+	})
+	if err != nil {
+		_ = iterator.Close()
+
+		return written, err
+	}
+
+	return written, iterator.Close()
+}
+
+func writeCSV[T any](csvWriter *csv.Writer, iterator StructIterator[T], header []string, format func(T) ([]string, error)) (int, error) {
+	var written int
+	defer csvWriter.Flush()
+
+	if err := csvWriter.Write(header); err != nil {
+		return written, err
+	}
+
+	for iterator.Next() {
+		item, err := iterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			return written, err
+		}
+
+		record, err := format(item)
+		if err != nil {
+			return written, fmt.Errorf("item %d: %w", written+1, err)
+		}
+
+		if err = csvWriter.Write(record); err != nil {
+			return written, err
+		}
+
+		written++
+	}
+
+	if err := iteratorErr(iterator); err != nil {
+		return written, err
+	}
+
+	csvWriter.Flush()
+
+	return written, csvWriter.Error()
+}
+
+// csvStructType returns the struct type mapped to CSV columns, when items are pointers to structs.
+func csvStructType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,238 @@
+package iterators
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	CSVAudit struct {
+		CreatedAt time.Time `csv:"created_at"`
+	}
+
+	csvTarget struct {
+		*CSVAudit
+		ID       int            `csv:"id"`
+		Label    string         `db:"label"`
+		Amount   float64        `csv:"amount" db:"ignored"`
+		Paid     bool           `csv:"paid"`
+		Comment  *string        `csv:"comment"`
+		Optional sql.NullString `csv:"optional"`
+		Address  netip.Addr     `csv:"address"`
+		Count    uint8
+		Ignored  int `csv:"-"`
+	}
+)
+
+func TestCSVIterator(t *testing.T) {
+	const input = `id,label,amount,paid,comment,optional,address,count,created_at
+1,first,10.5,true,note,x,10.0.0.1,3,2024-01-02T03:04:05Z
+2,second,-1,false,,,::1,,2024-02-03T00:00:00Z
+`
+
+	t.Run("should Collect mapped structs", func(t *testing.T) {
+		iterator := NewCSVIterator[csvTarget](strings.NewReader(input))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, []string{"id", "label", "amount", "paid", "comment", "optional", "address", "count", "created_at"}, iterator.Header())
+
+		first := items[0]
+		require.Equal(t, 1, first.ID)
+		require.Equal(t, "first", first.Label)
+		require.InDelta(t, 10.5, first.Amount, 1e-9)
+		require.True(t, first.Paid)
+		require.NotNil(t, first.Comment)
+		require.Equal(t, "note", *first.Comment)
+		require.Equal(t, sql.NullString{String: "x", Valid: true}, first.Optional)
+		require.Equal(t, netip.MustParseAddr("10.0.0.1"), first.Address)
+		require.Equal(t, uint8(3), first.Count)
+		require.NotNil(t, first.CSVAudit)
+		require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), first.CreatedAt)
+
+		second := items[1]
+		require.Nil(t, second.Comment)
+		require.False(t, second.Optional.Valid)
+		require.Zero(t, second.Count)
+	})
+
+	t.Run("should CollectPtr with options", func(t *testing.T) {
+		const semicolons = "id;created_at\n1;02/01/2024\n"
+		iterator := NewCSVIterator[csvTarget](strings.NewReader(semicolons),
+			WithCSVComma(';'),
+			WithCSVTimeLayout("02/01/2006"),
+			WithCSVPreallocatedItems(10),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, 10, cap(items))
+		require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), items[0].CreatedAt)
+	})
+
+	t.Run("should convert a single column into a scalar", func(t *testing.T) {
+		iterator := NewCSVIterator[int](strings.NewReader("value\n1\n2\n"))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("should allocate pointers to structs", func(t *testing.T) {
+		iterator := NewCSVIterator[*csvTarget](strings.NewReader(input))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.NotNil(t, items[1])
+		require.Equal(t, 2, items[1].ID)
+		require.Equal(t, "second", items[1].Label)
+	})
+
+	t.Run("should read cells as strings into an interface", func(t *testing.T) {
+		iterator := NewCSVIterator[any](strings.NewReader("value\n1\n\nx\n"))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []any{"1", "x"}, items)
+	})
+
+	t.Run("should report row and column in conversion errors", func(t *testing.T) {
+		const wrong = "id,amount\n1,2\n2,abc\n3,4\n"
+		iterator := NewCSVIterator[csvTarget](strings.NewReader(wrong))
+
+		items, err := CollectWithPolicy[csvTarget](iterator, SkipErrors())
+		require.Len(t, items, 2)
+		require.ErrorContains(t, err, "row 3, column 2 (amount)")
+	})
+
+	t.Run("should report malformed records", func(t *testing.T) {
+		const malformed = "id,amount\n1,2\n2\n3,4\n"
+		iterator := NewCSVIterator[csvTarget](strings.NewReader(malformed))
+
+		items, err := CollectWithPolicy[csvTarget](iterator, SkipErrors())
+		require.Len(t, items, 2)
+		require.ErrorIs(t, err, csv.ErrFieldCount)
+	})
+
+	t.Run("should stop on unknown column", func(t *testing.T) {
+		iterator := NewCSVIterator[csvTarget](strings.NewReader("id,unknown\n1,2\n"))
+
+		require.False(t, iterator.Next())
+		require.ErrorContains(t, iterator.Err(), "missing destination name unknown")
+	})
+
+	t.Run("should be empty with an empty reader", func(t *testing.T) {
+		iterator := NewCSVIterator[csvTarget](strings.NewReader(""))
+
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Err())
+		require.NoError(t, iterator.Close())
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Run("should write a header and one record per item", func(t *testing.T) {
+		comment := "note"
+		items := []csvTarget{
+			{
+				CSVAudit: &CSVAudit{CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+				ID:       1, Label: "first", Amount: 10.5, Paid: true, Comment: &comment,
+				Optional: sql.NullString{String: "x", Valid: true},
+				Address:  netip.MustParseAddr("10.0.0.1"),
+				Count:    3,
+			},
+			{ID: 2, Label: "second, with comma"},
+		}
+		var buf bytes.Buffer
+		tracker := newCloseTracker[csvTarget](NewSliceIterator(items))
+
+		written, err := WriteCSV[csvTarget](&buf, tracker)
+		require.NoError(t, err)
+		require.Equal(t, 2, written)
+		require.True(t, tracker.isClosed())
+		require.Equal(t, `created_at,id,label,amount,paid,comment,optional,address,count
+2024-01-02T03:04:05Z,1,first,10.5,true,note,x,10.0.0.1,3
+,2,"second, with comma",0,false,,,,0
+`, buf.String())
+
+		t.Run("should read back written records", func(t *testing.T) {
+			readBack, err := NewCSVIterator[csvTarget](&buf).Collect()
+			require.NoError(t, err)
+			require.Equal(t, items[0], readBack[0])
+			require.Equal(t, "second, with comma", readBack[1].Label)
+		})
+	})
+
+	t.Run("should write pointers to structs", func(t *testing.T) {
+		type row struct {
+			ID    int    `csv:"id"`
+			Label string `csv:"label"`
+		}
+		var buf bytes.Buffer
+
+		written, err := WriteCSV[*row](&buf, NewSliceIterator([]*row{{ID: 1, Label: "first"}, nil}))
+		require.NoError(t, err)
+		require.Equal(t, 2, written)
+		require.Equal(t, "id,label\n1,first\n,\n", buf.String())
+	})
+
+	t.Run("should write interfaces", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		written, err := WriteCSV[any](&buf, NewSliceIterator([]any{1, "x", nil}))
+		require.NoError(t, err)
+		require.Equal(t, 3, written)
+		require.Equal(t, "value\n1\nx\n\n", buf.String())
+	})
+
+	t.Run("should end on io.EOF", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		written, err := WriteCSV[int](&buf, newEOFTerminated[int](NewSliceIterator([]int{1, 2})))
+		require.NoError(t, err)
+		require.Equal(t, 2, written)
+		require.Equal(t, "value\n1\n2\n", buf.String())
+	})
+
+	t.Run("should write scalars", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		written, err := WriteCSV[int](&buf, NewSliceIterator([]int{1, 2}), WithCSVComma(';'))
+		require.NoError(t, err)
+		require.Equal(t, 2, written)
+		require.Equal(t, "value\n1\n2\n", buf.String())
+	})
+
+	t.Run("should stop on item error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in == 2 {
+				return 0, errTest
+			}
+
+			return in, nil
+		}
+		var buf bytes.Buffer
+		source := NewTransformIterator[int, int](context.Background(), NewSliceIterator([]int{1, 2, 3}), errorer)
+
+		written, err := WriteCSV[int](&buf, source)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, 1, written)
+		require.Equal(t, "value\n1\n", buf.String())
+	})
+}
@@ -0,0 +1,165 @@
+package iterators
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	valuerType          = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// isCSVValue tells if type t is converted from or to a single CSV cell, rather than mapped as a struct.
+func isCSVValue(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return isScannable(t) || t == timeType || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// parseCSVValue converts a CSV cell into the addressable value v.
+//
+// An empty cell is converted into a nil pointer, or the zero value.
+func parseCSVValue(v reflect.Value, cell string, timeLayout string) error {
+	if v.Kind() == reflect.Pointer {
+		if cell == "" {
+			v.Set(reflect.Zero(v.Type()))
+
+			return nil
+		}
+
+		ptr := reflect.New(v.Type().Elem())
+		if err := parseCSVValue(ptr.Elem(), cell, timeLayout); err != nil {
+			return err
+		}
+		v.Set(ptr)
+
+		return nil
+	}
+
+	if scanner, ok := v.Addr().Interface().(sql.Scanner); ok {
+		if cell == "" {
+			return scanner.Scan(nil)
+		}
+
+		return scanner.Scan(cell)
+	}
+
+	if cell == "" {
+		v.Set(reflect.Zero(v.Type()))
+
+		return nil
+	}
+
+	if v.Type() == timeType {
+		t, err := time.Parse(timeLayout, cell)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+
+		return nil
+	}
+
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(cell))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Interface:
+		if v.NumMethod() > 0 {
+			return fmt.Errorf("unsupported type %v", v.Type())
+		}
+		v.Set(reflect.ValueOf(cell))
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+
+	return nil
+}
+
+// formatCSVValue converts the value v into a CSV cell.
+//
+// A nil pointer, a nil interface or a NULL sql value is converted into an empty cell.
+func formatCSVValue(v reflect.Value, timeLayout string) (string, error) {
+	if !v.IsValid() {
+		return "", nil
+	}
+
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+
+		return formatCSVValue(v.Elem(), timeLayout)
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(timeLayout), nil
+	}
+
+	if v.Type().Implements(valuerType) {
+		value, err := v.Interface().(driver.Valuer).Value()
+		if err != nil || value == nil {
+			return "", err
+		}
+
+		return formatCSVValue(reflect.ValueOf(value), timeLayout)
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %v", v.Type())
+	}
+}
@@ -0,0 +1,237 @@
+package iterators
+
+import (
+	"container/list"
+	"io"
+	"iter"
+
+	"github.com/fredbi/go-patterns/sorters"
+)
+
+var (
+	_ StructIterator[dummy] = &DistinctIterator[dummy, int]{}
+	_ StructIterator[dummy] = &DistinctSortedIterator[dummy]{}
+)
+
+type (
+	// DistinctIterator removes duplicate items from any iterator, using a key computed for every item.
+	//
+	// Only the first item with a given key is delivered. Keys are retained in a hash set, which may be bounded
+	// with the WithDistinctMaxKeys() option, evicting the least recently seen keys.
+	//
+	// An error returned by the Item() method of the underlying iterator is reported by Item(), and the iteration goes on.
+	//
+	// Notice that the distinct iterator is not goroutine-safe and should not be iterated concurrently.
+	DistinctIterator[T any, K comparable] struct {
+		StructIterator[T]
+		key        func(T) K
+		seen       map[K]*list.Element
+		recent     *list.List // keys from the most to the least recently seen, when bounded
+		current    T
+		err        error
+		hasCurrent bool
+
+		*distinctIteratorOptions
+	}
+
+	// DistinctSortedIterator removes consecutive duplicate items from any iterator.
+	//
+	// Every item is only compared with the previous item: duplicates are all removed if the underlying iterator
+	// is sorted according to the comparison. No memory other than the previous item is used.
+	//
+	// An error returned by the Item() method of the underlying iterator is reported by Item(), and the iteration goes on.
+	//
+	// Notice that the distinct sorted iterator is not goroutine-safe and should not be iterated concurrently.
+	DistinctSortedIterator[T any] struct {
+		StructIterator[T]
+		comparison sorters.Comparison[T]
+		previous   T
+		started    bool
+		current    T
+		err        error
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+)
+
+// NewDistinctIterator makes a StructIterator[T] that only delivers the first item for every key of type K.
+func NewDistinctIterator[T any, K comparable](iterator StructIterator[T], key func(T) K, opts ...DistinctIteratorOption) *DistinctIterator[T, K] {
+	options := distinctIteratorOptionsWithDefault(opts)
+
+	di := &DistinctIterator[T, K]{
+		StructIterator:          iterator,
+		key:                     key,
+		seen:                    make(map[K]*list.Element),
+		distinctIteratorOptions: options,
+	}
+
+	if options.maxKeys > 0 {
+		di.recent = list.New()
+	}
+
+	return di
+}
+
+// DistinctSorted makes a StructIterator[T] that skips items equal to the previous one, according to a comparison.
+func DistinctSorted[T any](iterator StructIterator[T], comparison sorters.Comparison[T], opts ...RowsIteratorOption) *DistinctSortedIterator[T] {
+	return &DistinctSortedIterator[T]{
+		StructIterator:      iterator,
+		comparison:          comparison,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (di *DistinctIterator[T, K]) Next() bool {
+	var empty T
+	di.current = empty
+	di.err = nil
+	di.hasCurrent = false
+
+	for di.StructIterator.Next() {
+		item, err := di.StructIterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			di.err = err
+			di.hasCurrent = true
+
+			return true
+		}
+
+		if di.isDuplicate(di.key(item)) {
+			continue
+		}
+
+		di.current = item
+		di.hasCurrent = true
+
+		return true
+	}
+
+	return false
+}
+
+// isDuplicate tells if a key has been seen already, and records it otherwise.
+func (di *DistinctIterator[T, K]) isDuplicate(key K) bool {
+	element, found := di.seen[key]
+
+	if di.recent == nil {
+		if !found {
+			di.seen[key] = nil
+		}
+
+		return found
+	}
+
+	if found {
+		di.recent.MoveToFront(element)
+
+		return true
+	}
+
+	di.seen[key] = di.recent.PushFront(key)
+	if di.recent.Len() > di.maxKeys {
+		oldest := di.recent.Back()
+		di.recent.Remove(oldest)
+		delete(di.seen, oldest.Value.(K))
+	}
+
+	return false
+}
+
+func (di *DistinctIterator[T, K]) Item() (T, error) {
+	if !di.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return di.current, di.err
+}
+
+// Err returns the error reported by the underlying iterator, if any.
+func (di *DistinctIterator[T, K]) Err() error {
+	return iteratorErr(di.StructIterator)
+}
+
+func (di *DistinctIterator[T, K]) Collect() ([]T, error) {
+	return collectAndClose[T](di, di.preallocatedItems)
+}
+
+func (di *DistinctIterator[T, K]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](di, di.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (di *DistinctIterator[T, K]) All() iter.Seq2[T, error] {
+	return Seq[T](di)
+}
+
+func (ds *DistinctSortedIterator[T]) Next() bool {
+	var empty T
+	ds.current = empty
+	ds.err = nil
+	ds.hasCurrent = false
+
+	for ds.StructIterator.Next() {
+		item, err := ds.StructIterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			ds.err = err
+			ds.hasCurrent = true
+
+			return true
+		}
+
+		if ds.started && ds.comparison(ds.previous, item) == 0 {
+			continue
+		}
+
+		ds.started = true
+		ds.previous = item
+		ds.current = item
+		ds.hasCurrent = true
+
+		return true
+	}
+
+	return false
+}
+
+func (ds *DistinctSortedIterator[T]) Item() (T, error) {
+	if !ds.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return ds.current, ds.err
+}
+
+// Err returns the error reported by the underlying iterator, if any.
+func (ds *DistinctSortedIterator[T]) Err() error {
+	return iteratorErr(ds.StructIterator)
+}
+
+func (ds *DistinctSortedIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](ds, ds.preallocatedItems)
+}
+
+func (ds *DistinctSortedIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](ds, ds.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ds *DistinctSortedIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](ds)
+}
@@ -0,0 +1,135 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fredbi/go-patterns/sorters"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistinctIterator(t *testing.T) {
+	byA := func(in dummyStruct) int {
+		return in.A
+	}
+	duplicates := func() []dummyStruct {
+		return []dummyStruct{{A: 1, B: "a"}, {A: 2, B: "b"}, {A: 1, B: "c"}, {A: 3, B: "d"}, {A: 2, B: "e"}}
+	}
+
+	t.Run("should Collect the first item for every key", func(t *testing.T) {
+		iterator := NewDistinctIterator[dummyStruct](NewSliceIterator(duplicates()), byA)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []dummyStruct{{A: 1, B: "a"}, {A: 2, B: "b"}, {A: 3, B: "d"}}, items)
+	})
+
+	t.Run("should CollectPtr distinct items", func(t *testing.T) {
+		iterator := NewDistinctIterator[dummyStruct](NewSliceIterator(duplicates()), byA,
+			WithDistinctPreallocatedItems(10),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		require.Equal(t, 10, cap(items))
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		iterator := NewDistinctIterator[dummyStruct](newEOFTerminated[dummyStruct](NewSliceIterator(duplicates())), byA)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+	})
+
+	t.Run("should evict the least recently seen keys when bounded", func(t *testing.T) {
+		keys := []int{1, 2, 1, 3, 2, 1}
+		iterator := NewDistinctIterator[int](NewSliceIterator(keys), func(in int) int { return in },
+			WithDistinctMaxKeys(2),
+		)
+
+		// 1, 2: retained
+		// 1: duplicate, refreshed
+		// 3: retained, evicts 2
+		// 2: retained again, evicts 1
+		// 1: retained again
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3, 2, 1}, items)
+		require.Len(t, iterator.seen, 2)
+	})
+
+	t.Run("should report item errors", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in dummyStruct) (dummyStruct, error) {
+			if in.B == "c" {
+				return dummyStruct{}, errTest
+			}
+
+			return in, nil
+		}
+		source := NewTransformIterator[dummyStruct, dummyStruct](context.Background(), NewSliceIterator(duplicates()), errorer)
+		iterator := NewDistinctIterator[dummyStruct](source, byA)
+
+		var count int
+		var errs []error
+		for item, err := range iterator.All() {
+			if err != nil {
+				errs = append(errs, err)
+
+				break
+			}
+			require.NotEmpty(t, item)
+			count++
+		}
+
+		require.Equal(t, 2, count)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], errTest)
+	})
+}
+
+func TestDistinctSortedIterator(t *testing.T) {
+	compareA := func(a, b dummyStruct) int {
+		return sorters.OrderedComparator[int]()(a.A, b.A)
+	}
+
+	t.Run("should skip consecutive duplicates", func(t *testing.T) {
+		sorted := []dummyStruct{{A: 1, B: "a"}, {A: 1, B: "b"}, {A: 2, B: "c"}, {A: 3, B: "d"}, {A: 3, B: "e"}}
+		iterator := DistinctSorted[dummyStruct](NewSliceIterator(sorted), compareA)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []dummyStruct{{A: 1, B: "a"}, {A: 2, B: "c"}, {A: 3, B: "d"}}, items)
+	})
+
+	t.Run("should only compare with the previous item", func(t *testing.T) {
+		iterator := DistinctSorted[int](NewSliceIterator([]int{1, 2, 1, 1}), sorters.OrderedComparator[int](),
+			WithRowsPreallocatedItems(10),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		require.Equal(t, 10, cap(items))
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		iterator := DistinctSorted[int](newEOFTerminated[int](NewSliceIterator([]int{1, 1, 2})), sorters.OrderedComparator[int]())
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("should close the underlying iterator", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator([]int{1, 2}))
+		iterator := DistinctSorted[int](tracker, sorters.OrderedComparator[int]())
+
+		require.True(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+		require.True(t, tracker.isClosed())
+	})
+}
@@ -0,0 +1,42 @@
+package iterators_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fredbi/go-patterns/iterators"
+)
+
+func ExampleFilterIterator() {
+	baseIterator := iterators.NewSliceIterator[SampleStruct](testSlice())
+
+	predicate := func(ctx context.Context, in SampleStruct) (bool, error) {
+		ictx := iterators.GetIteratorContext(ctx)
+		if ictx != nil {
+			fmt.Printf("filtering iteration %d (emitted so far: %d)\n", ictx.Iterated, ictx.Emitted)
+		}
+
+		return in.B == "y", nil
+	}
+
+	iterator := iterators.NewFilterIterator[SampleStruct](context.Background(), baseIterator, predicate)
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	for iterator.Next() {
+		item, err := iterator.Item()
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+
+			break
+		}
+
+		fmt.Printf("item: %#v\n", item)
+	}
+
+	// Output:
+	// filtering iteration 1 (emitted so far: 0)
+	// filtering iteration 2 (emitted so far: 0)
+	// item: iterators_test.SampleStruct{A:2, B:"y"}
+}
@@ -0,0 +1,32 @@
+package iterators_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fredbi/go-patterns/iterators"
+	"github.com/fredbi/go-patterns/sorters"
+)
+
+func ExampleMergeIterator() {
+	// each input is already sorted, e.g. the result of the same query with an ORDER BY clause against several shards
+	baseIterators := []iterators.StructIterator[SampleStruct]{
+		iterators.NewSliceIterator[SampleStruct]([]SampleStruct{{A: 1, B: "x"}, {A: 3, B: "x"}}),
+		iterators.NewSliceIterator[SampleStruct]([]SampleStruct{{A: 2, B: "y"}, {A: 4, B: "y"}}),
+	}
+
+	byA := func(a, b SampleStruct) int {
+		return sorters.OrderedComparator[int]()(a.A, b.A)
+	}
+
+	iterator := iterators.NewMergeIterator[SampleStruct](context.Background(), baseIterators, byA)
+	items, err := iterator.Collect()
+	if err != nil {
+		fmt.Printf("err: %v\n", err)
+	}
+
+	fmt.Printf("items: %v\n", SortableStructs(items))
+
+	// Output:
+	// items: [{1 x}, {2 y}, {3 x}, {4 y}]
+}
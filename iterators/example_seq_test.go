@@ -0,0 +1,26 @@
+package iterators_test
+
+import (
+	"fmt"
+
+	"github.com/fredbi/go-patterns/iterators"
+)
+
+func ExampleSeq() {
+	iterator := iterators.NewSliceIterator[SampleStruct](testSlice())
+
+	// the iterator is closed when the loop is done
+	for item, err := range iterators.Seq[SampleStruct](iterator) {
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+
+			break
+		}
+
+		fmt.Printf("item: %#v\n", item)
+	}
+
+	// Output:
+	// item: iterators_test.SampleStruct{A:1, B:"x"}
+	// item: iterators_test.SampleStruct{A:2, B:"y"}
+}
@@ -0,0 +1,41 @@
+package iterators_test
+
+import (
+	"fmt"
+
+	"github.com/fredbi/go-patterns/iterators"
+)
+
+func ExampleZip() {
+	source := iterators.NewSliceIterator[SampleStruct](testSlice())
+	target := iterators.NewSliceIterator[SampleStruct]([]SampleStruct{{A: 1, B: "x"}})
+
+	iterator := iterators.Zip[SampleStruct, SampleStruct](source, target, iterators.WithZipLongest())
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	for iterator.Next() {
+		pair, err := iterator.Item()
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+
+			break
+		}
+
+		switch {
+		case pair.RightMissing:
+			fmt.Printf("missing in target: %#v\n", pair.Left)
+		case pair.LeftMissing:
+			fmt.Printf("unexpected in target: %#v\n", pair.Right)
+		case pair.Left != pair.Right:
+			fmt.Printf("mismatch: %#v != %#v\n", pair.Left, pair.Right)
+		default:
+			fmt.Printf("match: %#v\n", pair.Left)
+		}
+	}
+
+	// Output:
+	// match: iterators_test.SampleStruct{A:1, B:"x"}
+	// missing in target: iterators_test.SampleStruct{A:2, B:"y"}
+}
@@ -0,0 +1,132 @@
+package iterators
+
+import (
+	"context"
+	"io"
+	"iter"
+)
+
+var _ StructIterator[dummy] = &FilterIterator[dummy]{}
+
+type (
+	// PredicateCtx decides if an item of type T should be retained, with a context about the state of the iterator.
+	PredicateCtx[T any] func(context.Context, T) (bool, error)
+
+	// FilterIterator filters any iterator, skipping the items rejected by a predicate.
+	//
+	// Rejected items are skipped by Next(), so Item() only returns accepted items.
+	//
+	// Notice that the filter iterator is not goroutine-safe and should not be iterated concurrently.
+	FilterIterator[T any] struct {
+		StructIterator[T]
+		iterated   int
+		emitted    int
+		ctx        context.Context
+		predicate  PredicateCtx[T]
+		current    T
+		err        error
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+)
+
+// NewFilterIterator makes a StructIterator[T] that only retains items accepted by a predicate.
+//
+// The parent context provided allows the predicate to know about the current context of the iterator,
+// using GetIteratorContext.
+//
+// An error returned by the underlying iterator or by the predicate is reported by Item().
+func NewFilterIterator[T any](ctx context.Context, iterator StructIterator[T], predicate PredicateCtx[T], opts ...RowsIteratorOption) *FilterIterator[T] {
+	return &FilterIterator[T]{
+		StructIterator:      iterator,
+		ctx:                 ctx,
+		predicate:           predicate,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (rf *FilterIterator[T]) iteratorContext() context.Context {
+	return context.WithValue(rf.ctx, ctxKeyIteration, &IteratorContext{Iterated: rf.iterated, Emitted: rf.emitted})
+}
+
+func (rf *FilterIterator[T]) iteratedCount() int {
+	return rf.iterated
+}
+
+func (rf *FilterIterator[T]) Next() bool {
+	var empty T
+	rf.hasCurrent = false
+	rf.current = empty
+	rf.err = nil
+
+	for rf.StructIterator.Next() {
+		rf.iterated++
+
+		item, err := rf.StructIterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			rf.err = err
+			rf.hasCurrent = true
+
+			return true
+		}
+
+		accepted, err := rf.predicate(rf.iteratorContext(), item)
+		if err != nil {
+			rf.err = err
+			rf.hasCurrent = true
+
+			return true
+		}
+
+		if accepted {
+			rf.emitted++
+			rf.current = item
+			rf.hasCurrent = true
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rf *FilterIterator[T]) Item() (T, error) {
+	if rf.err != nil {
+		var empty T
+
+		return empty, rf.err
+	}
+
+	if !rf.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return rf.current, nil
+}
+
+// Err returns the error reported by the source iterator, if any.
+func (rf *FilterIterator[T]) Err() error {
+	return iteratorErr(rf.StructIterator)
+}
+
+func (rf *FilterIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](rf, rf.preallocatedItems)
+}
+
+func (rf *FilterIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](rf, rf.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (rf *FilterIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](rf)
+}
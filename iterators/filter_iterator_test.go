@@ -0,0 +1,113 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterIterator(t *testing.T) {
+	oddOnly := func(_ context.Context, in dummyStruct) (bool, error) {
+		return in.A%2 == 1, nil
+	}
+	numbers := func() []dummyStruct {
+		return []dummyStruct{{A: 1}, {A: 2}, {A: 3}, {A: 4}, {A: 5}}
+	}
+
+	t.Run("should Collect filtered items", func(t *testing.T) {
+		iterator := NewFilterIterator[dummyStruct](context.Background(), NewSliceIterator(numbers()), oddOnly)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []dummyStruct{{A: 1}, {A: 3}, {A: 5}}, items)
+	})
+
+	t.Run("should CollectPtr filtered items", func(t *testing.T) {
+		iterator := NewFilterIterator[dummyStruct](context.Background(), NewSliceIterator(numbers()), oddOnly,
+			WithRowsPreallocatedItems(10),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		require.Equal(t, 10, cap(items))
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		iterator := NewFilterIterator[dummyStruct](context.Background(), newEOFTerminated[dummyStruct](NewSliceIterator(numbers())), oddOnly)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []dummyStruct{{A: 1}, {A: 3}, {A: 5}}, items)
+	})
+
+	t.Run("should expose iterated and emitted counts", func(t *testing.T) {
+		var contexts []IteratorContext
+		recorder := func(ctx context.Context, in dummyStruct) (bool, error) {
+			ictx := GetIteratorContext(ctx)
+			require.NotNil(t, ictx)
+			contexts = append(contexts, *ictx)
+
+			return in.A%2 == 1, nil
+		}
+		iterator := NewFilterIterator[dummyStruct](context.Background(), NewSliceIterator(numbers()), recorder)
+
+		_, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []IteratorContext{
+			{Iterated: 1, Emitted: 0},
+			{Iterated: 2, Emitted: 1},
+			{Iterated: 3, Emitted: 1},
+			{Iterated: 4, Emitted: 2},
+			{Iterated: 5, Emitted: 2},
+		}, contexts)
+	})
+
+	t.Run("should reject all items", func(t *testing.T) {
+		none := func(_ context.Context, _ dummyStruct) (bool, error) {
+			return false, nil
+		}
+		iterator := NewFilterIterator[dummyStruct](context.Background(), NewSliceIterator(numbers()), none)
+
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should error on predicate error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in dummyStruct) (bool, error) {
+			if in.A > 3 {
+				return false, errTest
+			}
+
+			return true, nil
+		}
+		iterator := NewFilterIterator[dummyStruct](context.Background(), NewSliceIterator(numbers()), errorer)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, items, 3)
+	})
+
+	t.Run("should error on input error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in dummyStruct) (dummyStruct, error) {
+			if in.A > 2 {
+				return dummyStruct{}, errTest
+			}
+
+			return in, nil
+		}
+		errorIterator := NewTransformIterator[dummyStruct, dummyStruct](context.Background(), NewSliceIterator(numbers()), errorer)
+		iterator := NewFilterIterator[dummyStruct](context.Background(), errorIterator, oddOnly)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, items, 1)
+	})
+}
@@ -0,0 +1,209 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+)
+
+var _ StructIterator[dummy] = &FlatMapIterator[dummy, dummy]{}
+
+type (
+	// FlatMapperCtx expands a struct of type S into a slice of items of type T,
+	// with a context about the state of the iterator.
+	FlatMapperCtx[S, T any] func(context.Context, S) ([]T, error)
+
+	// FlatMapperNestedCtx expands a struct of type S into an iterator over items of type T,
+	// with a context about the state of the iterator.
+	FlatMapperNestedCtx[S, T any] func(context.Context, S) (StructIterator[T], error)
+
+	// FlatMapIterator transforms any iterator over items of type S into an iterator over items of type T,
+	// every input item being expanded into zero, one or several output items.
+	//
+	// Inner iterators are drained lazily, then closed before advancing the outer iterator.
+	//
+	// Errors returned by the Item() method of the outer iterator, by the transformer or by the Item() method of
+	// an inner iterator are returned by Item(), and the iteration continues.
+	//
+	// An error reported by the Err() method of an inner iterator, or returned when closing it,
+	// interrupts the iteration and is reported by Err().
+	//
+	// Notice that the flat map iterator is not goroutine-safe and should not be iterated concurrently.
+	FlatMapIterator[S, T any] struct {
+		StructIterator[S]
+		iterated   int
+		emitted    int
+		ctx        context.Context
+		expander   FlatMapperNestedCtx[S, T]
+		inner      StructIterator[T]
+		current    T
+		itemErr    error
+		err        error
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+)
+
+// NewFlatMapIterator makes a StructIterator[T] from a StructIterator[S], expanding every input item into a slice of items.
+//
+// Like with TransformIterator, the parent context provided allows the transformer to know about the current context of the iterator.
+func NewFlatMapIterator[S, T any](ctx context.Context, iterator StructIterator[S], transformer FlatMapperCtx[S, T], opts ...RowsIteratorOption) *FlatMapIterator[S, T] {
+	return NewFlatMapNestedIterator[S, T](ctx, iterator, func(ictx context.Context, input S) (StructIterator[T], error) {
+		items, err := transformer(ictx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSliceIterator[T](items), nil
+	}, opts...)
+}
+
+// NewFlatMapNestedIterator makes a StructIterator[T] from a StructIterator[S], expanding every input item into an inner iterator.
+//
+// Inner iterators are closed as soon as they are drained, or when the FlatMapIterator is closed.
+func NewFlatMapNestedIterator[S, T any](ctx context.Context, iterator StructIterator[S], expander FlatMapperNestedCtx[S, T], opts ...RowsIteratorOption) *FlatMapIterator[S, T] {
+	return &FlatMapIterator[S, T]{
+		StructIterator:      iterator,
+		ctx:                 ctx,
+		expander:            expander,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (fm *FlatMapIterator[S, T]) iteratorContext() context.Context {
+	return context.WithValue(fm.ctx, ctxKeyIteration, &IteratorContext{Iterated: fm.iterated, Emitted: fm.emitted})
+}
+
+func (fm *FlatMapIterator[S, T]) iteratedCount() int {
+	return fm.iterated
+}
+
+func (fm *FlatMapIterator[S, T]) Next() bool {
+	var empty T
+	fm.hasCurrent = false
+	fm.current = empty
+	fm.itemErr = nil
+
+	if fm.err != nil {
+		return false
+	}
+
+	for {
+		if fm.inner != nil {
+			if fm.inner.Next() {
+				item, err := fm.inner.Item()
+				if errors.Is(err, io.EOF) {
+					// the inner iterator is drained (e.g. a ChanIterator)
+					continue
+				}
+
+				if err == nil {
+					fm.emitted++
+				}
+
+				return fm.deliver(item, err)
+			}
+
+			if err := iteratorErr(fm.inner); err != nil {
+				return fm.fail(err)
+			}
+
+			if err := fm.closeInner(); err != nil {
+				return fm.fail(err)
+			}
+		}
+
+		if !fm.StructIterator.Next() {
+			return false
+		}
+		fm.iterated++
+
+		input, err := fm.StructIterator.Item()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+
+		if err != nil {
+			return fm.deliver(empty, err)
+		}
+
+		inner, err := fm.expander(fm.iteratorContext(), input)
+		if err != nil {
+			return fm.deliver(empty, err)
+		}
+
+		fm.inner = inner
+	}
+}
+
+func (fm *FlatMapIterator[S, T]) deliver(item T, err error) bool {
+	fm.current = item
+	fm.itemErr = err
+	fm.hasCurrent = true
+
+	return true
+}
+
+func (fm *FlatMapIterator[S, T]) fail(err error) bool {
+	fm.err = err
+
+	return false
+}
+
+func (fm *FlatMapIterator[S, T]) closeInner() error {
+	if fm.inner == nil {
+		return nil
+	}
+
+	inner := fm.inner
+	fm.inner = nil
+
+	return inner.Close()
+}
+
+func (fm *FlatMapIterator[S, T]) Item() (T, error) {
+	if !fm.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return fm.current, fm.itemErr
+}
+
+// Err returns the error that interrupted the iteration, if any.
+func (fm *FlatMapIterator[S, T]) Err() error {
+	if fm.err != nil {
+		return fm.err
+	}
+
+	return iteratorErr(fm.StructIterator)
+}
+
+// Close the current inner iterator, if any, then the outer iterator.
+func (fm *FlatMapIterator[S, T]) Close() error {
+	innerErr := fm.closeInner()
+
+	if err := fm.StructIterator.Close(); err != nil {
+		return err
+	}
+
+	return innerErr
+}
+
+func (fm *FlatMapIterator[S, T]) Collect() ([]T, error) {
+	return collectAndClose[T](fm, fm.preallocatedItems)
+}
+
+func (fm *FlatMapIterator[S, T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](fm, fm.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (fm *FlatMapIterator[S, T]) All() iter.Seq2[T, error] {
+	return Seq[T](fm)
+}
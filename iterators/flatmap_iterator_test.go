@@ -0,0 +1,158 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatMapIterator(t *testing.T) {
+	splitter := func(_ context.Context, in dummyStruct) ([]string, error) {
+		if in.B == "" {
+			return nil, nil
+		}
+
+		return strings.Split(in.B, ","), nil
+	}
+	parents := func() []dummyStruct {
+		return []dummyStruct{{A: 1, B: "a,b"}, {A: 2}, {A: 3, B: "c"}, {A: 4, B: "d,e,f"}}
+	}
+
+	t.Run("should Collect expanded items", func(t *testing.T) {
+		iterator := NewFlatMapIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), splitter)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, items)
+	})
+
+	t.Run("should CollectPtr expanded items", func(t *testing.T) {
+		iterator := NewFlatMapIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), splitter,
+			WithRowsPreallocatedItems(10),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 6)
+		require.Equal(t, 10, cap(items))
+	})
+
+	t.Run("should expose iterated and emitted counts", func(t *testing.T) {
+		var contexts []IteratorContext
+		recorder := func(ctx context.Context, in dummyStruct) ([]string, error) {
+			ictx := GetIteratorContext(ctx)
+			require.NotNil(t, ictx)
+			contexts = append(contexts, *ictx)
+
+			return splitter(ctx, in)
+		}
+		iterator := NewFlatMapIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), recorder)
+
+		_, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []IteratorContext{
+			{Iterated: 1, Emitted: 0},
+			{Iterated: 2, Emitted: 2},
+			{Iterated: 3, Emitted: 2},
+			{Iterated: 4, Emitted: 3},
+		}, contexts)
+	})
+
+	t.Run("should drain and close inner iterators", func(t *testing.T) {
+		var trackers []*closeTracker[string]
+		expander := func(ctx context.Context, in dummyStruct) (StructIterator[string], error) {
+			items, _ := splitter(ctx, in)
+			tracker := newCloseTracker[string](NewSliceIterator(items))
+			trackers = append(trackers, tracker)
+
+			return tracker, nil
+		}
+		iterator := NewFlatMapNestedIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), expander)
+
+		require.True(t, iterator.Next())
+		require.True(t, iterator.Next())
+		require.Len(t, trackers, 1)
+		require.False(t, trackers[0].isClosed())
+
+		require.True(t, iterator.Next())
+		item, err := iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, "c", item)
+		require.Len(t, trackers, 3)
+		require.True(t, trackers[0].isClosed())
+		require.True(t, trackers[1].isClosed())
+		require.False(t, trackers[2].isClosed())
+
+		require.NoError(t, iterator.Close())
+		require.True(t, trackers[2].isClosed())
+	})
+
+	t.Run("with empty input", func(t *testing.T) {
+		iterator := NewFlatMapIterator[dummyStruct, string](context.Background(), NewSliceIterator[dummyStruct](nil), splitter)
+
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should error on transformer error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(ctx context.Context, in dummyStruct) ([]string, error) {
+			if in.A > 2 {
+				return nil, errTest
+			}
+
+			return splitter(ctx, in)
+		}
+		iterator := NewFlatMapIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), errorer)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, items, 2)
+
+		t.Run("should continue after transformer errors", func(t *testing.T) {
+			iterator := NewFlatMapIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), errorer)
+
+			items, err := CollectWithPolicy[string](iterator, SkipErrors())
+			require.Equal(t, []string{"a", "b"}, items)
+
+			var itemErrs ItemErrors
+			require.ErrorAs(t, err, &itemErrs)
+			require.Len(t, itemErrs, 2)
+			require.NoError(t, iterator.Err())
+		})
+	})
+
+	t.Run("should error on inner iterator error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		expander := func(ctx context.Context, in dummyStruct) (StructIterator[string], error) {
+			items, _ := splitter(ctx, in)
+
+			return NewTransformIterator[string, string](ctx, NewSliceIterator(items), func(_ context.Context, s string) (string, error) {
+				if s == "b" {
+					return "", errTest
+				}
+
+				return s, nil
+			}), nil
+		}
+		iterator := NewFlatMapNestedIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), expander)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, []string{"a"}, items)
+
+		t.Run("should continue after inner item errors", func(t *testing.T) {
+			iterator := NewFlatMapNestedIterator[dummyStruct, string](context.Background(), NewSliceIterator(parents()), expander)
+
+			items, err := CollectWithPolicy[string](iterator, SkipErrors())
+			require.ErrorIs(t, err, errTest)
+			require.Equal(t, []string{"a", "c", "d", "e", "f"}, items)
+		})
+	})
+}
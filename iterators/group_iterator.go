@@ -0,0 +1,250 @@
+package iterators
+
+import (
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/fredbi/go-patterns/sorters"
+)
+
+var (
+	_ StructIterator[Group[int, dummy]]     = &GroupByIterator[dummy, int]{}
+	_ StructIterator[Aggregate[int, dummy]] = &AggregateIterator[dummy, int, dummy]{}
+)
+
+type (
+	// Group holds consecutive items of type T sharing the same key of type K.
+	Group[K, T any] struct {
+		Key   K
+		Items []T
+	}
+
+	// Aggregate holds the value of type A aggregated over consecutive items sharing the same key of type K.
+	Aggregate[K, A any] struct {
+		Key   K
+		Value A
+	}
+
+	// Aggregator folds an item of type T into the value aggregated so far for a group.
+	//
+	// The first item of every group is folded into the zero value of A.
+	Aggregator[T, A any] func(A, T) A
+
+	// GroupByIterator regroups the consecutive items of a sorted iterator sharing the same key,
+	// like a SQL GROUP BY clause.
+	//
+	// A group is delivered whenever the key changes. Items are expected to be sorted by key:
+	// otherwise, several groups may be delivered for the same key.
+	//
+	// An error returned by the Item() method of the underlying iterator ends the group being built,
+	// which is delivered first. The error is then returned by Item() with an empty group, and the iteration continues.
+	//
+	// An error reported by the Err() method of the underlying iterator interrupts the iteration, and is reported by Err().
+	// The group being built is not delivered.
+	//
+	// Notice that the group-by iterator is not goroutine-safe and should not be iterated concurrently.
+	GroupByIterator[T, K any] struct {
+		*grouper[T, K, []T]
+	}
+
+	// AggregateIterator works like the GroupByIterator, but folds the items of every group into a single value
+	// instead of retaining all items.
+	//
+	// Notice that the aggregate iterator is not goroutine-safe and should not be iterated concurrently.
+	AggregateIterator[T, K, A any] struct {
+		*grouper[T, K, A]
+	}
+
+	// grouper folds the consecutive items of an iterator sharing the same key.
+	grouper[T, K, A any] struct {
+		StructIterator[T]
+		key        func(T) K
+		equal      func(K, K) bool
+		aggregator Aggregator[T, A]
+		next       T     // first item of the next group
+		nextErr    error // item error met instead of the first item of the next group
+		hasNext    bool
+		current    Aggregate[K, A]
+		itemErr    error
+		err        error
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+)
+
+// NewGroupByIterator makes a StructIterator[Group[K, T]] regrouping consecutive items with the same key.
+func NewGroupByIterator[T any, K comparable](iterator StructIterator[T], key func(T) K, opts ...RowsIteratorOption) *GroupByIterator[T, K] {
+	return &GroupByIterator[T, K]{
+		grouper: newGrouper[T, K, []T](iterator, key, equalKeys[K], appendItem[T], opts),
+	}
+}
+
+// NewGroupByIteratorFunc makes a StructIterator[Group[K, T]] regrouping consecutive items with the same key,
+// keys being compared with a sorters.Comparison.
+func NewGroupByIteratorFunc[T, K any](iterator StructIterator[T], key func(T) K, comparison sorters.Comparison[K], opts ...RowsIteratorOption) *GroupByIterator[T, K] {
+	return &GroupByIterator[T, K]{
+		grouper: newGrouper[T, K, []T](iterator, key, equalFunc(comparison), appendItem[T], opts),
+	}
+}
+
+// NewAggregateIterator makes a StructIterator[Aggregate[K, A]] aggregating consecutive items with the same key.
+func NewAggregateIterator[T any, K comparable, A any](iterator StructIterator[T], key func(T) K, aggregator Aggregator[T, A], opts ...RowsIteratorOption) *AggregateIterator[T, K, A] {
+	return &AggregateIterator[T, K, A]{
+		grouper: newGrouper[T, K, A](iterator, key, equalKeys[K], aggregator, opts),
+	}
+}
+
+// NewAggregateIteratorFunc makes a StructIterator[Aggregate[K, A]] aggregating consecutive items with the same key,
+// keys being compared with a sorters.Comparison.
+func NewAggregateIteratorFunc[T, K, A any](iterator StructIterator[T], key func(T) K, comparison sorters.Comparison[K], aggregator Aggregator[T, A], opts ...RowsIteratorOption) *AggregateIterator[T, K, A] {
+	return &AggregateIterator[T, K, A]{
+		grouper: newGrouper[T, K, A](iterator, key, equalFunc(comparison), aggregator, opts),
+	}
+}
+
+func newGrouper[T, K, A any](iterator StructIterator[T], key func(T) K, equal func(K, K) bool, aggregator Aggregator[T, A], opts []RowsIteratorOption) *grouper[T, K, A] {
+	return &grouper[T, K, A]{
+		StructIterator:      iterator,
+		key:                 key,
+		equal:               equal,
+		aggregator:          aggregator,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func equalKeys[K comparable](a, b K) bool {
+	return a == b
+}
+
+func equalFunc[K any](comparison sorters.Comparison[K]) func(K, K) bool {
+	return func(a, b K) bool {
+		return comparison(a, b) == 0
+	}
+}
+
+func appendItem[T any](items []T, item T) []T {
+	return append(items, item)
+}
+
+func (g *grouper[T, K, A]) Next() bool {
+	g.current = Aggregate[K, A]{}
+	g.itemErr = nil
+	g.hasCurrent = false
+
+	if g.err != nil {
+		return false
+	}
+
+	if !g.hasNext {
+		if !g.pull() {
+			return false
+		}
+	}
+
+	if g.nextErr != nil {
+		g.itemErr = g.nextErr
+		g.hasCurrent = true
+		g.hasNext = false
+
+		return true
+	}
+
+	groupKey := g.key(g.next)
+	var value A
+
+	for g.hasNext && g.nextErr == nil {
+		item := g.next
+		if !g.equal(groupKey, g.key(item)) {
+			break
+		}
+
+		value = g.aggregator(value, item)
+
+		if !g.pull() && g.err != nil {
+			return false
+		}
+	}
+
+	g.current = Aggregate[K, A]{Key: groupKey, Value: value}
+	g.hasCurrent = true
+
+	return true
+}
+
+// pull retrieves the next item, or item error, from the underlying iterator.
+func (g *grouper[T, K, A]) pull() bool {
+	var empty T
+	g.next = empty
+	g.nextErr = nil
+	g.hasNext = false
+
+	for g.StructIterator.Next() {
+		item, err := g.StructIterator.Item()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+
+		g.next = item
+		g.nextErr = err
+		g.hasNext = true
+
+		return true
+	}
+
+	g.err = iteratorErr(g.StructIterator)
+
+	return false
+}
+
+// Err returns the error that interrupted the iteration, if any.
+func (g *grouper[T, K, A]) Err() error {
+	return g.err
+}
+
+func (gi *GroupByIterator[T, K]) Item() (Group[K, T], error) {
+	if !gi.hasCurrent {
+		return Group[K, T]{}, io.EOF
+	}
+
+	return Group[K, T]{Key: gi.current.Key, Items: gi.current.Value}, gi.itemErr
+}
+
+func (gi *GroupByIterator[T, K]) Collect() ([]Group[K, T], error) {
+	return collectAndClose[Group[K, T]](gi, gi.preallocatedItems)
+}
+
+func (gi *GroupByIterator[T, K]) CollectPtr() ([]*Group[K, T], error) {
+	return collectPtrAndClose[Group[K, T]](gi, gi.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (gi *GroupByIterator[T, K]) All() iter.Seq2[Group[K, T], error] {
+	return Seq[Group[K, T]](gi)
+}
+
+func (ai *AggregateIterator[T, K, A]) Item() (Aggregate[K, A], error) {
+	if !ai.hasCurrent {
+		return Aggregate[K, A]{}, io.EOF
+	}
+
+	return ai.current, ai.itemErr
+}
+
+func (ai *AggregateIterator[T, K, A]) Collect() ([]Aggregate[K, A], error) {
+	return collectAndClose[Aggregate[K, A]](ai, ai.preallocatedItems)
+}
+
+func (ai *AggregateIterator[T, K, A]) CollectPtr() ([]*Aggregate[K, A], error) {
+	return collectPtrAndClose[Aggregate[K, A]](ai, ai.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ai *AggregateIterator[T, K, A]) All() iter.Seq2[Aggregate[K, A], error] {
+	return Seq[Aggregate[K, A]](ai)
+}
@@ -0,0 +1,153 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fredbi/go-patterns/sorters"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupByIterator(t *testing.T) {
+	byA := func(in dummyStruct) int {
+		return in.A
+	}
+	sorted := func() []dummyStruct {
+		return []dummyStruct{{A: 1, B: "a"}, {A: 1, B: "b"}, {A: 2, B: "c"}, {A: 3, B: "d"}, {A: 3, B: "e"}}
+	}
+
+	t.Run("should Collect groups", func(t *testing.T) {
+		iterator := NewGroupByIterator[dummyStruct](NewSliceIterator(sorted()), byA)
+
+		groups, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []Group[int, dummyStruct]{
+			{Key: 1, Items: []dummyStruct{{A: 1, B: "a"}, {A: 1, B: "b"}}},
+			{Key: 2, Items: []dummyStruct{{A: 2, B: "c"}}},
+			{Key: 3, Items: []dummyStruct{{A: 3, B: "d"}, {A: 3, B: "e"}}},
+		}, groups)
+	})
+
+	t.Run("should CollectPtr groups", func(t *testing.T) {
+		iterator := NewGroupByIterator[dummyStruct](NewSliceIterator(sorted()), byA, WithRowsPreallocatedItems(10))
+
+		groups, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+		require.Equal(t, 10, cap(groups))
+	})
+
+	t.Run("should compare keys with a sorters.Comparison", func(t *testing.T) {
+		words := []string{"Apple", "avocado", "Banana", "blueberry", "cherry"}
+		firstLetter := func(in string) string {
+			return in[:1]
+		}
+		caseInsensitive := func(a, b string) int {
+			return sorters.StringsComparator()(strings.ToLower(a), strings.ToLower(b))
+		}
+		iterator := NewGroupByIteratorFunc[string](NewSliceIterator(words), firstLetter, caseInsensitive)
+
+		groups, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []Group[string, string]{
+			{Key: "A", Items: []string{"Apple", "avocado"}},
+			{Key: "B", Items: []string{"Banana", "blueberry"}},
+			{Key: "c", Items: []string{"cherry"}},
+		}, groups)
+	})
+
+	t.Run("should be empty with an empty iterator", func(t *testing.T) {
+		iterator := NewGroupByIterator[dummyStruct](NewSliceIterator([]dummyStruct{}), byA)
+
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should deliver the current group then the item error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in dummyStruct) (dummyStruct, error) {
+			if in.B == "d" {
+				return dummyStruct{}, errTest
+			}
+
+			return in, nil
+		}
+		source := NewTransformIterator[dummyStruct, dummyStruct](context.Background(), NewSliceIterator(sorted()), errorer)
+		iterator := NewGroupByIterator[dummyStruct](source, byA)
+
+		groups, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, groups, 2)
+
+		t.Run("should continue after the item error", func(t *testing.T) {
+			source := NewTransformIterator[dummyStruct, dummyStruct](context.Background(), NewSliceIterator(sorted()), errorer)
+			iterator := NewGroupByIterator[dummyStruct](source, byA)
+
+			groups, err := CollectWithPolicy[Group[int, dummyStruct]](iterator, SkipErrors())
+			require.ErrorIs(t, err, errTest)
+			require.Equal(t, []Group[int, dummyStruct]{
+				{Key: 1, Items: []dummyStruct{{A: 1, B: "a"}, {A: 1, B: "b"}}},
+				{Key: 2, Items: []dummyStruct{{A: 2, B: "c"}}},
+				{Key: 3, Items: []dummyStruct{{A: 3, B: "e"}}},
+			}, groups)
+			require.NoError(t, iterator.Err())
+		})
+	})
+
+	t.Run("should stop on failure without delivering the current group", func(t *testing.T) {
+		errCursor := errors.New("cursor error")
+		rows := newFakeRows([]string{"a", "b"}, []any{1, "a"}, []any{2, "b"}, []any{2, "c"})
+		rows.err = errCursor
+		iterator := NewGroupByIterator[dummyStruct](NewScanIterator[*fakeRows, dummyStruct](rows), byA)
+
+		groups, err := iterator.Collect()
+		require.ErrorIs(t, err, errCursor)
+		require.Len(t, groups, 1)
+		require.ErrorIs(t, iterator.Err(), errCursor)
+	})
+}
+
+func TestAggregateIterator(t *testing.T) {
+	type sale struct {
+		Region string
+		Amount int
+	}
+	byRegion := func(in sale) string {
+		return in.Region
+	}
+	sum := func(total int, in sale) int {
+		return total + in.Amount
+	}
+	sales := func() []sale {
+		return []sale{{"east", 10}, {"east", 5}, {"north", 7}, {"west", 1}, {"west", 2}}
+	}
+
+	t.Run("should aggregate groups", func(t *testing.T) {
+		iterator := NewAggregateIterator[sale](NewSliceIterator(sales()), byRegion, sum)
+
+		totals, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []Aggregate[string, int]{
+			{Key: "east", Value: 15},
+			{Key: "north", Value: 7},
+			{Key: "west", Value: 3},
+		}, totals)
+	})
+
+	t.Run("should aggregate groups with a sorters.Comparison", func(t *testing.T) {
+		count := func(n int, _ sale) int {
+			return n + 1
+		}
+		iterator := NewAggregateIteratorFunc[sale](NewSliceIterator(sales()), byRegion, sorters.StringsComparator(), count)
+
+		counts, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, counts, 3)
+		require.Equal(t, Aggregate[string, int]{Key: "east", Value: 2}, *counts[0])
+	})
+}
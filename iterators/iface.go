@@ -29,10 +29,23 @@ type (
 		CollectPtr() ([]*T, error)
 	}
 
+	// ErrIterator is an iterator that reports the error that interrupted the iteration, if any.
+	//
+	// Like with bufio.Scanner, Err should be checked after Next() returns false,
+	// in order to tell the normal end of the stream from a failure (e.g. a dropped connection).
+	//
+	// Errors on individual items are returned by Item() and are not reported by Err: the iteration continues.
+	// Iterators wrapping other iterators pass on the item errors of their source through Item() as well.
+	// The ChanIterator is the exception: the first item error of an input stops all its workers, and is reported by Err().
+	ErrIterator interface {
+		Err() error
+	}
+
 	dummy struct{}
 
 	baseIterator[T any] interface {
 		Iterator
+		ErrIterator
 		Item() (T, error)
 	}
 )
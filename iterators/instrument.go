@@ -0,0 +1,134 @@
+package iterators
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"time"
+)
+
+var _ StructIterator[dummy] = &InstrumentedIterator[dummy]{}
+
+type (
+	// Observer is notified about the lifecycle of an InstrumentedIterator.
+	//
+	// An Observer may be shared by several iterators, so implementations should be goroutine-safe.
+	Observer interface {
+		// OnFirstItem is called when the first item is successfully retrieved, with the time elapsed since the iterator
+		// was instrumented.
+		OnFirstItem(elapsed time.Duration)
+
+		// OnItem is called for every item successfully retrieved, with the time elapsed from the call to Next()
+		// until the item is returned by Item().
+		//
+		// This includes the work done lazily by Item(), e.g. by a TransformIterator or while waiting on a ChanIterator.
+		OnItem(latency time.Duration)
+
+		// OnItemError is called for every error returned by Item().
+		OnItemError(err error)
+
+		// OnClose is called once when the iterator is closed, with the time elapsed since the iterator was instrumented.
+		//
+		// The error is the error that interrupted the iteration, if any, or the error returned by Close().
+		OnClose(elapsed time.Duration, err error)
+	}
+
+	// InstrumentedIterator wraps any iterator and notifies an Observer about its lifecycle.
+	//
+	// Notice that the instrumented iterator is not goroutine-safe and should not be iterated concurrently.
+	InstrumentedIterator[T any] struct {
+		StructIterator[T]
+		observer  Observer
+		start     time.Time
+		nextStart time.Time
+		hasFirst  bool
+		observed  bool
+		isClosed  bool
+
+		*rowsIteratorOptions
+	}
+)
+
+// Instrument makes an InstrumentedIterator[T] notifying an Observer about the items yielded by an iterator.
+//
+// The time to the first item and the time the iterator stays open are measured from the call to Instrument.
+//
+// Items are observed when they are retrieved by Item(): positions skipped without calling Item() are not notified.
+func Instrument[T any](iterator StructIterator[T], observer Observer, opts ...RowsIteratorOption) *InstrumentedIterator[T] {
+	return &InstrumentedIterator[T]{
+		StructIterator:      iterator,
+		observer:            observer,
+		start:               time.Now(),
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (ii *InstrumentedIterator[T]) Next() bool {
+	ii.nextStart = time.Now()
+	ii.observed = false
+
+	return ii.StructIterator.Next()
+}
+
+func (ii *InstrumentedIterator[T]) Item() (T, error) {
+	item, err := ii.StructIterator.Item()
+	if ii.observed || errors.Is(err, io.EOF) {
+		return item, err
+	}
+	ii.observed = true
+
+	if err != nil {
+		ii.observer.OnItemError(err)
+
+		return item, err
+	}
+
+	latency := time.Since(ii.nextStart)
+	if !ii.hasFirst {
+		ii.hasFirst = true
+		ii.observer.OnFirstItem(time.Since(ii.start))
+	}
+	ii.observer.OnItem(latency)
+
+	return item, nil
+}
+
+// Err returns the error reported by the underlying iterator, if any.
+func (ii *InstrumentedIterator[T]) Err() error {
+	return iteratorErr(ii.StructIterator)
+}
+
+// Close the underlying iterator and notify the Observer.
+//
+// Subsequent calls to Close() do nothing.
+func (ii *InstrumentedIterator[T]) Close() error {
+	if ii.isClosed {
+		return nil
+	}
+	ii.isClosed = true
+
+	err := ii.StructIterator.Close()
+
+	if iterationErr := ii.Err(); iterationErr != nil {
+		ii.observer.OnClose(time.Since(ii.start), iterationErr)
+	} else {
+		ii.observer.OnClose(time.Since(ii.start), err)
+	}
+
+	return err
+}
+
+func (ii *InstrumentedIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](ii, ii.preallocatedItems)
+}
+
+func (ii *InstrumentedIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](ii, ii.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ii *InstrumentedIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](ii)
+}
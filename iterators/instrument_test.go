@@ -0,0 +1,184 @@
+package iterators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	mx         sync.Mutex
+	firstItems int
+	items      int
+	itemErrs   []error
+	closed     int
+	closeErr   error
+}
+
+func (o *recordingObserver) OnFirstItem(_ time.Duration) {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	o.firstItems++
+}
+
+func (o *recordingObserver) OnItem(_ time.Duration) {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	o.items++
+}
+
+func (o *recordingObserver) OnItemError(err error) {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	o.itemErrs = append(o.itemErrs, err)
+}
+
+func (o *recordingObserver) OnClose(_ time.Duration, err error) {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	o.closed++
+	o.closeErr = err
+}
+
+func TestInstrument(t *testing.T) {
+	errTest := errors.New("test error")
+
+	t.Run("should notify items and close", func(t *testing.T) {
+		observer := &recordingObserver{}
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(dummySlice()))
+		iterator := Instrument[dummyStruct](tracker, observer, WithRowsPreallocatedItems(10))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, len(dummySlice()))
+		require.Equal(t, 10, cap(items))
+		require.True(t, tracker.isClosed())
+
+		require.Equal(t, 1, observer.firstItems)
+		require.Equal(t, len(dummySlice()), observer.items)
+		require.Empty(t, observer.itemErrs)
+		require.Equal(t, 1, observer.closed)
+		require.NoError(t, observer.closeErr)
+
+		t.Run("should close only once", func(t *testing.T) {
+			require.NoError(t, iterator.Close())
+			require.Equal(t, 1, observer.closed)
+			require.Equal(t, int32(1), tracker.closed.Load())
+		})
+	})
+
+	t.Run("should notify each item once", func(t *testing.T) {
+		observer := &recordingObserver{}
+		iterator := Instrument[int](NewSliceIterator([]int{1, 2}), observer)
+
+		require.True(t, iterator.Next())
+		_, err := iterator.Item()
+		require.NoError(t, err)
+		_, err = iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, 1, observer.items)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should notify item errors", func(t *testing.T) {
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in%2 == 0 {
+				return 0, errTest
+			}
+
+			return in, nil
+		}
+		observer := &recordingObserver{}
+		source := NewTransformIterator[int, int](context.Background(), NewSliceIterator([]int{1, 2, 3, 4}), errorer)
+
+		items, err := CollectWithPolicy[int](Instrument[int](source, observer), SkipErrors())
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, []int{1, 3}, items)
+		require.Equal(t, 2, observer.items)
+		require.Len(t, observer.itemErrs, 2)
+		require.Equal(t, 1, observer.closed)
+		require.NoError(t, observer.closeErr)
+	})
+
+	t.Run("should notify the error that interrupted the iteration", func(t *testing.T) {
+		errCursor := errors.New("cursor error")
+		rows := newFakeRows([]string{"id"}, []any{1})
+		rows.err = errCursor
+		observer := &recordingObserver{}
+
+		iterator := Instrument[int](NewScanIterator[*fakeRows, int](rows), observer)
+		_, err := iterator.Collect()
+		require.ErrorIs(t, err, errCursor)
+		require.ErrorIs(t, observer.closeErr, errCursor)
+		require.True(t, rows.closed)
+	})
+
+	t.Run("should not notify a first item when empty", func(t *testing.T) {
+		observer := &recordingObserver{}
+		iterator := Instrument[int](NewSliceIterator([]int{}), observer)
+
+		for range iterator.All() {
+			require.Fail(t, "unexpected item")
+		}
+		require.Zero(t, observer.firstItems)
+		require.Equal(t, 1, observer.closed)
+	})
+}
+
+func TestInstrumentLatency(t *testing.T) {
+	const delay = 20 * time.Millisecond
+	slow := func(_ context.Context, in int) (int, error) {
+		time.Sleep(delay)
+
+		return in, nil
+	}
+	metrics := NewMetrics()
+	source := NewTransformIterator[int, int](context.Background(), NewSliceIterator([]int{1, 2, 3}), slow)
+
+	items, err := Instrument[int](source, metrics).Collect()
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	// the transformer runs within Item(): its duration is part of the latency
+	snapshot := metrics.Snapshot()
+	require.GreaterOrEqual(t, snapshot.AvgItemLatency, delay)
+	require.GreaterOrEqual(t, snapshot.AvgTimeToFirstItem, delay)
+	require.GreaterOrEqual(t, snapshot.AvgOpenDuration, 3*delay)
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, _ = Instrument[dummyStruct](NewSliceIterator(dummySlice()), metrics).Collect()
+		}()
+	}
+	wg.Wait()
+
+	metrics.OnItemError(errors.New("test error"))
+	metrics.OnClose(time.Hour, errors.New("test error"))
+
+	snapshot := metrics.Snapshot()
+	require.Equal(t, int64(4*len(dummySlice())), snapshot.Items)
+	require.Equal(t, int64(1), snapshot.ItemErrors)
+	require.Equal(t, int64(5), snapshot.Closed)
+	require.Equal(t, int64(1), snapshot.Failed)
+	require.Equal(t, time.Hour, snapshot.MaxOpenDuration)
+	require.GreaterOrEqual(t, snapshot.MaxItemLatency, snapshot.AvgItemLatency)
+
+	t.Run("should export as JSON for expvar", func(t *testing.T) {
+		var exported MetricsSnapshot
+		require.NoError(t, json.Unmarshal([]byte(metrics.String()), &exported))
+		require.Equal(t, snapshot, exported)
+	})
+}
@@ -8,9 +8,11 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"strconv"
 	"sync"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	// initialize DB driver
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
@@ -40,10 +42,34 @@ func UniqueDBName() string {
 }
 
 func OpenDB(dbName string) (*sqlx.DB, error) {
-	u, err := url.Parse(base)
+	u, err := dbURL(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sqlx.Open("pgx", u)
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// OpenPgxConn opens a native pgx connection to a test DB.
+func OpenPgxConn(dbName string) (*pgx.Conn, error) {
+	u, err := dbURL(dbName)
 	if err != nil {
 		return nil, err
 	}
+
+	return pgx.Connect(context.Background(), u)
+}
+
+func dbURL(dbName string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
 	u.Path = dbName
 
 	info := u.User
@@ -75,12 +101,7 @@ func OpenDB(dbName string) (*sqlx.DB, error) {
 
 	log.Printf("DEBUG: postgres URL: %s", u.String())
 
-	db, err := sqlx.Open("pgx", u.String())
-	if err != nil {
-		return nil, err
-	}
-
-	return db, nil
+	return u.String(), nil
 }
 
 func CloseDB(db *sqlx.DB) error {
@@ -113,6 +134,20 @@ func CreateDBWithWrongData(dbName string) (*sqlx.DB, error) {
 	return db, err
 }
 
+// CreateDBWithRows creates a DB with n rows in the dummy table: (1, "1"), (2, "2"), ...
+func CreateDBWithRows(dbName string, n int) (*sqlx.DB, error) {
+	db, err := createDB(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = createRows(db, n); err != nil {
+		return nil, err
+	}
+
+	return db, err
+}
+
 func createDB(dbName string) (*sqlx.DB, error) {
 	emptyDB, err := OpenDB("")
 	if err != nil {
@@ -159,6 +194,31 @@ func createData(db *sqlx.DB) error {
 	return err
 }
 
+func createRows(db *sqlx.DB, n int) error {
+	_, err := db.Exec("CREATE TABLE dummy(a integer, b character varying)")
+	if err != nil {
+		return err
+	}
+
+	builder := sq.
+		Insert(
+			"dummy",
+		).
+		Columns(
+			"a", "b",
+		).
+		PlaceholderFormat(sq.Dollar)
+
+	for i := 1; i <= n; i++ {
+		builder = builder.Values(i, strconv.Itoa(i))
+	}
+
+	insert, args := builder.MustSql()
+	_, err = db.Exec(insert, args...)
+
+	return err
+}
+
 func createWrongData(db *sqlx.DB) error {
 	_, err := db.Exec("CREATE TABLE dummy(a integer, b character varying)")
 	if err != nil {
@@ -207,3 +267,15 @@ func EmptyDBCursor(db *sqlx.DB) (*sqlx.Rows, error) {
 
 	return db.QueryxContext(context.Background(), query, args...)
 }
+
+func OpenPgxCursor(conn *pgx.Conn) (pgx.Rows, error) {
+	query, args := sq.Select(
+		"a", "b",
+	).From(
+		"dummy",
+	).OrderBy(
+		"a",
+	).MustSql()
+
+	return conn.Query(context.Background(), query, args...)
+}
@@ -0,0 +1,160 @@
+package iterators
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+var _ StructIterator[dummy] = &JSONLinesIterator[dummy]{}
+
+// JSONLinesIterator decodes items of type T from a stream of newline-delimited JSON (NDJSON).
+//
+// Every non-blank line is decoded into a T. Errors mention the line number, starting at 1.
+//
+// A line that cannot be decoded is reported by Item(), and the iteration goes on.
+// An error when reading the stream, or a line exceeding the maximum size (see WithJSONLinesMaxLineSize),
+// interrupts the iteration and is reported by Err().
+//
+// Notice that the JSON lines iterator is not goroutine-safe and should not be iterated concurrently.
+type JSONLinesIterator[T any] struct {
+	scanner *bufio.Scanner
+	line    int
+	err     error
+	started bool
+	done    bool
+
+	*jsonLinesIteratorOptions
+}
+
+// NewJSONLinesIterator makes a JSONLinesIterator[T] producing items of type T from a reader.
+//
+// The reader is not closed by the iterator.
+func NewJSONLinesIterator[T any](reader io.Reader, opts ...JSONLinesIteratorOption) *JSONLinesIterator[T] {
+	options := jsonLinesIteratorOptionsWithDefault(opts)
+	scanner := bufio.NewScanner(reader)
+	// leave room for the line terminator ("\n" or "\r\n"): the size of lines is checked by Next()
+	scanner.Buffer(make([]byte, 0, min(options.maxLineSize, bufio.MaxScanTokenSize)), options.maxLineSize+2)
+
+	return &JSONLinesIterator[T]{
+		scanner:                  scanner,
+		jsonLinesIteratorOptions: options,
+	}
+}
+
+func (ji *JSONLinesIterator[T]) Next() bool {
+	if ji.done {
+		return false
+	}
+
+	for ji.scanner.Scan() {
+		ji.line++
+
+		if len(ji.scanner.Bytes()) > ji.maxLineSize {
+			ji.done = true
+			ji.err = fmt.Errorf("line %d: %w", ji.line, bufio.ErrTooLong)
+
+			return false
+		}
+
+		if len(bytes.TrimSpace(ji.scanner.Bytes())) == 0 {
+			continue
+		}
+
+		ji.started = true
+
+		return true
+	}
+
+	ji.done = true
+	if err := ji.scanner.Err(); err != nil {
+		ji.err = fmt.Errorf("line %d: %w", ji.line+1, err)
+	}
+
+	return false
+}
+
+func (ji *JSONLinesIterator[T]) Item() (T, error) {
+	var data T
+
+	if !ji.started || ji.done {
+		return data, io.EOF
+	}
+
+	if err := json.Unmarshal(ji.scanner.Bytes(), &data); err != nil {
+		return data, fmt.Errorf("line %d: %w", ji.line, err)
+	}
+
+	return data, nil
+}
+
+// Err returns the error that interrupted the reading of the stream, if any.
+func (ji *JSONLinesIterator[T]) Err() error {
+	return ji.err
+}
+
+// Close stops the iteration. The underlying reader is not closed.
+func (ji *JSONLinesIterator[T]) Close() error {
+	ji.done = true
+
+	return nil
+}
+
+func (ji *JSONLinesIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](ji, ji.preallocatedItems)
+}
+
+func (ji *JSONLinesIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](ji, ji.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ji *JSONLinesIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](ji)
+}
+
+// WriteJSONLines encodes all items from an iterator to a writer as newline-delimited JSON (NDJSON),
+// then closes the iterator.
+//
+// Items are written one at a time, as they are iterated, so they are never all held in memory.
+//
+// The writing stops at the first error, either from the iterator or from the writer.
+// It returns the number of items written.
+func WriteJSONLines[T any](writer io.Writer, iterator StructIterator[T]) (int, error) {
+	encoder := json.NewEncoder(writer)
+	var written int
+
+	for iterator.Next() {
+		item, err := iterator.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			_ = iterator.Close()
+
+			return written, err
+		}
+
+		if err = encoder.Encode(item); err != nil {
+			_ = iterator.Close()
+
+			return written, fmt.Errorf("item %d: %w", written+1, err)
+		}
+
+		written++
+	}
+
+	if err := iteratorErr(iterator); err != nil {
+		_ = iterator.Close()
+
+		return written, err
+	}
+
+	return written, iterator.Close()
+}
@@ -0,0 +1,140 @@
+package iterators
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type jsonLine struct {
+	A int    `json:"a"`
+	B string `json:"b"`
+}
+
+func TestJSONLinesIterator(t *testing.T) {
+	t.Run("should Collect decoded lines", func(t *testing.T) {
+		input := "{\"a\":1,\"b\":\"x\"}\n\n  \n{\"a\":2,\"b\":\"y\"}"
+		iterator := NewJSONLinesIterator[jsonLine](strings.NewReader(input))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []jsonLine{{A: 1, B: "x"}, {A: 2, B: "y"}}, items)
+	})
+
+	t.Run("should CollectPtr decoded lines", func(t *testing.T) {
+		input := "{\"a\":1}\n{\"a\":2}\n"
+		iterator := NewJSONLinesIterator[jsonLine](strings.NewReader(input), WithJSONLinesPreallocatedItems(10))
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, 10, cap(items))
+	})
+
+	t.Run("should report line numbers in decoding errors", func(t *testing.T) {
+		input := "{\"a\":1}\n\n{\"a\":\"wrong\"}\n{\"a\":3}\n"
+		iterator := NewJSONLinesIterator[jsonLine](strings.NewReader(input))
+
+		items, err := CollectWithPolicy[jsonLine](iterator, SkipErrors())
+		require.Equal(t, []jsonLine{{A: 1}, {A: 3}}, items)
+		require.ErrorContains(t, err, "line 3: json: cannot unmarshal")
+	})
+
+	t.Run("should stop on lines exceeding the max size", func(t *testing.T) {
+		input := "{\"a\":1}\n{\"a\":2,\"b\":\"" + strings.Repeat("x", 100) + "\"}\n{\"a\":3}\n"
+		iterator := NewJSONLinesIterator[jsonLine](strings.NewReader(input), WithJSONLinesMaxLineSize(32))
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, bufio.ErrTooLong)
+		require.ErrorContains(t, err, "line 2")
+		require.Equal(t, []jsonLine{{A: 1}}, items)
+	})
+
+	t.Run("should accept lines of exactly the max size", func(t *testing.T) {
+		iterator := NewJSONLinesIterator[int](strings.NewReader("1234\r\n5678\n12345\n"), WithJSONLinesMaxLineSize(4))
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, bufio.ErrTooLong)
+		require.ErrorContains(t, err, "line 3")
+		require.Equal(t, []int{1234, 5678}, items)
+	})
+
+	t.Run("should ignore a max size lower than 1", func(t *testing.T) {
+		iterator := NewJSONLinesIterator[int](strings.NewReader("1\n2\n"), WithJSONLinesMaxLineSize(-1))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("should be empty with an empty reader", func(t *testing.T) {
+		iterator := NewJSONLinesIterator[jsonLine](strings.NewReader(""))
+
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Err())
+		require.NoError(t, iterator.Close())
+	})
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	t.Run("should write one line per item", func(t *testing.T) {
+		var buf bytes.Buffer
+		tracker := newCloseTracker[jsonLine](NewSliceIterator([]jsonLine{{A: 1, B: "x"}, {A: 2, B: "y"}}))
+
+		written, err := WriteJSONLines[jsonLine](&buf, tracker)
+		require.NoError(t, err)
+		require.Equal(t, 2, written)
+		require.Equal(t, "{\"a\":1,\"b\":\"x\"}\n{\"a\":2,\"b\":\"y\"}\n", buf.String())
+		require.True(t, tracker.isClosed())
+
+		t.Run("should read back written lines", func(t *testing.T) {
+			items, err := NewJSONLinesIterator[jsonLine](&buf).Collect()
+			require.NoError(t, err)
+			require.Equal(t, []jsonLine{{A: 1, B: "x"}, {A: 2, B: "y"}}, items)
+		})
+	})
+
+	t.Run("should end on io.EOF", func(t *testing.T) {
+		var buf bytes.Buffer
+		source := newEOFTerminated[jsonLine](NewSliceIterator([]jsonLine{{A: 1}, {A: 2}, {A: 3}}))
+
+		written, err := WriteJSONLines[jsonLine](&buf, source)
+		require.NoError(t, err)
+		require.Equal(t, 3, written)
+	})
+
+	t.Run("should stop on item error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in jsonLine) (jsonLine, error) {
+			if in.A == 2 {
+				return jsonLine{}, errTest
+			}
+
+			return in, nil
+		}
+		var buf bytes.Buffer
+		source := NewTransformIterator[jsonLine, jsonLine](context.Background(), NewSliceIterator([]jsonLine{{A: 1}, {A: 2}, {A: 3}}), errorer)
+
+		written, err := WriteJSONLines[jsonLine](&buf, source)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, 1, written)
+		require.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	})
+
+	t.Run("should stop on encoding error", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		written, err := WriteJSONLines[any](&buf, NewSliceIterator([]any{1, make(chan int)}))
+		require.Error(t, err)
+		require.ErrorContains(t, err, "item 2")
+		require.Equal(t, 1, written)
+	})
+}
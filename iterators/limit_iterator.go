@@ -0,0 +1,201 @@
+package iterators
+
+import (
+	"io"
+	"iter"
+)
+
+var (
+	_ StructIterator[dummy] = &LimitIterator[dummy]{}
+	_ StructIterator[dummy] = &SkipIterator[dummy]{}
+)
+
+type (
+	// LimitIterator delivers at most a given number of items from any iterator.
+	//
+	// The underlying iterator is closed as soon as the limit is reached, e.g. to release a database cursor early.
+	//
+	// Notice that the limit iterator is not goroutine-safe and should not be iterated concurrently.
+	LimitIterator[T any] struct {
+		releasable[T]
+		limit      int
+		emitted    int
+		current    T
+		err        error
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+
+	// SkipIterator skips a given number of items from any iterator, then delivers all the remaining items.
+	//
+	// Skipped items are not retrieved with Item(), so they are not scanned or transformed.
+	//
+	// Notice that the skip iterator is not goroutine-safe and should not be iterated concurrently.
+	SkipIterator[T any] struct {
+		releasable[T]
+		skip int
+
+		*rowsIteratorOptions
+	}
+
+	// releasable wraps a source iterator that may be closed before the wrapping iterator is closed.
+	releasable[T any] struct {
+		source     StructIterator[T]
+		released   bool
+		releaseErr error
+	}
+)
+
+// Limit makes a StructIterator[T] delivering at most limit items from the underlying iterator.
+//
+// A limit lower than 1 produces an empty iterator.
+func Limit[T any](iterator StructIterator[T], limit int, opts ...RowsIteratorOption) *LimitIterator[T] {
+	return &LimitIterator[T]{
+		releasable:          releasable[T]{source: iterator},
+		limit:               limit,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+// Skip makes a StructIterator[T] that skips the first skip items from the underlying iterator.
+//
+// Together with Limit, this may be used to serve an offset/limit page out of any iterator:
+//
+//	page := Limit[T](Skip[T](iterator, offset), limit)
+func Skip[T any](iterator StructIterator[T], skip int, opts ...RowsIteratorOption) *SkipIterator[T] {
+	return &SkipIterator[T]{
+		releasable:          releasable[T]{source: iterator},
+		skip:                skip,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (li *LimitIterator[T]) Next() bool {
+	var empty T
+	li.current = empty
+	li.hasCurrent = false
+	li.err = nil
+
+	if li.released {
+		return false
+	}
+
+	if li.emitted >= li.limit || !li.source.Next() {
+		li.release()
+
+		return false
+	}
+
+	item, err := li.source.Item()
+	if isEndOfStream(err) {
+		li.release()
+
+		return false
+	}
+
+	li.emitted++
+	li.current, li.err = item, err
+	li.hasCurrent = true
+
+	if li.emitted >= li.limit {
+		// the current item has been retrieved: the source is no longer needed
+		li.release()
+	}
+
+	return true
+}
+
+func (li *LimitIterator[T]) Item() (T, error) {
+	if !li.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return li.current, li.err
+}
+
+func (li *LimitIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](li, min(li.preallocatedItems, max(li.limit, 0)))
+}
+
+func (li *LimitIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](li, min(li.preallocatedItems, max(li.limit, 0)))
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (li *LimitIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](li)
+}
+
+func (si *SkipIterator[T]) Next() bool {
+	if si.released {
+		return false
+	}
+
+	for ; si.skip > 0; si.skip-- {
+		if !si.source.Next() {
+			return false
+		}
+	}
+
+	return si.source.Next()
+}
+
+func (si *SkipIterator[T]) Item() (T, error) {
+	if si.released {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return si.source.Item()
+}
+
+func (si *SkipIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](si, si.preallocatedItems)
+}
+
+func (si *SkipIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](si, si.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (si *SkipIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](si)
+}
+
+// release closes the source iterator early. The error returned by Close() is reported by Err().
+func (r *releasable[T]) release() {
+	if r.released {
+		return
+	}
+
+	r.released = true
+	r.releaseErr = r.source.Close()
+}
+
+// Close the underlying iterator, unless it has already been released.
+func (r *releasable[T]) Close() error {
+	if r.released {
+		return nil
+	}
+
+	r.released = true
+
+	return r.source.Close()
+}
+
+// Err returns the error reported by the underlying iterator, or the error that occurred when releasing it early.
+func (r *releasable[T]) Err() error {
+	if r.releaseErr != nil {
+		return r.releaseErr
+	}
+
+	return iteratorErr(r.source)
+}
@@ -0,0 +1,136 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitIterator(t *testing.T) {
+	t.Run("should Collect at most limit items", func(t *testing.T) {
+		iterator := Limit[int](NewSliceIterator(intSlice(5)), 3)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3}, items)
+	})
+
+	t.Run("should CollectPtr all items when the limit is not reached", func(t *testing.T) {
+		iterator := Limit[int](NewSliceIterator(intSlice(5)), 10)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 5)
+		require.Equal(t, 10, cap(items))
+	})
+
+	t.Run("should close the source as soon as the limit is reached", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(5)))
+		iterator := Limit[int](tracker, 2)
+
+		require.True(t, iterator.Next())
+		require.False(t, tracker.isClosed())
+		require.True(t, iterator.Next())
+		require.True(t, tracker.isClosed())
+
+		item, err := iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, 2, item)
+
+		require.False(t, iterator.Next())
+		_, err = iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+
+		require.NoError(t, iterator.Close())
+		require.Equal(t, int32(1), tracker.closed.Load())
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		tracker := newCloseTracker[int](newEOFTerminated[int](NewSliceIterator(intSlice(3))))
+		iterator := Limit[int](tracker, 5)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, intSlice(3), items)
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should be empty with a zero limit", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(5)))
+		iterator := Limit[int](tracker, 0)
+
+		require.False(t, iterator.Next())
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should report item errors", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in == 2 {
+				return 0, errTest
+			}
+
+			return in, nil
+		}
+		iterator := Limit[int](NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(5)), errorer), 3)
+
+		_, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("should serve an offset/limit page", func(t *testing.T) {
+		iterator := Limit[int](Skip[int](NewSliceIterator(intSlice(5)), 1), 2)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 3}, items)
+	})
+}
+
+func TestSkipIterator(t *testing.T) {
+	t.Run("should skip the first items", func(t *testing.T) {
+		iterator := Skip[int](NewSliceIterator(intSlice(5)), 2)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{3, 4, 5}, items)
+	})
+
+	t.Run("should be empty when skipping all items", func(t *testing.T) {
+		iterator := Skip[int](NewSliceIterator(intSlice(5)), 10)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Empty(t, items)
+	})
+
+	t.Run("should not retrieve skipped items", func(t *testing.T) {
+		var transformed int
+		counter := func(_ context.Context, in int) (int, error) {
+			transformed++
+
+			return in, nil
+		}
+		iterator := Skip[int](NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(5)), counter), 3)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{4, 5}, items)
+		require.Equal(t, 2, transformed)
+	})
+
+	t.Run("should stop after Close()", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(5)))
+		iterator := Skip[int](tracker, 1)
+
+		require.True(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+		require.True(t, tracker.isClosed())
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+	})
+}
@@ -0,0 +1,306 @@
+package iterators
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"sync"
+
+	"github.com/fredbi/go-patterns/sorters"
+	"golang.org/x/sync/errgroup"
+)
+
+var _ StructIterator[dummy] = &MergeIterator[dummy]{}
+
+type (
+	// MergeIterator merges a collection of sorted StructIterators into a single sorted stream.
+	//
+	// Every input iterator is assumed to be already sorted according to the same comparison.
+	// This is typically the case when the same query with an ORDER BY clause is run against several database shards.
+	//
+	// Like ChanIterator, all input iterators are fetched in parallel, each in its own goroutine.
+	// WithMergeBuffers may be used to pre-fetch more items from every input.
+	//
+	// Items comparing equal are delivered in the order of the input iterators.
+	//
+	// Unlike ChanIterator, errors returned by the Item() method of an input iterator are returned by Item()
+	// as soon as they are received, and the iteration continues.
+	//
+	// The first error reported by the Err() method of an input iterator interrupts the iteration, and is reported by Err().
+	//
+	// Notice that the merge iterator is not goroutine-safe and should not be iterated concurrently.
+	MergeIterator[T any] struct {
+		inputs      []chan mergeEntry[T]
+		errs        []error
+		queue       *mergeQueue[T]
+		last        int
+		current     T
+		itemErr     error
+		itemErrs    []error // item errors received, not delivered yet
+		err         error
+		started     bool
+		done        bool
+		isClosed    bool
+		workerGroup *errgroup.Group
+		ctx         context.Context
+		parentCtx   context.Context
+		cancel      context.CancelFunc
+		mx          sync.Mutex
+
+		*mergeIteratorOptions
+	}
+
+	mergeEntry[T any] struct {
+		item  T
+		err   error
+		input int
+	}
+
+	mergeQueue[T any] struct {
+		entries    []mergeEntry[T]
+		comparison sorters.Comparison[T]
+	}
+)
+
+// NewMergeIterator builds a MergeIterator and starts the goroutines pumping items from the input iterators.
+//
+// All goroutines are terminated and input iterators closed if the context is cancelled,
+// or when the iterator is closed.
+func NewMergeIterator[T any](ctx context.Context, iterators []StructIterator[T], comparison sorters.Comparison[T], opts ...MergeIteratorOption) *MergeIterator[T] {
+	cancellableCtx, cancel := context.WithCancel(ctx)
+	workerGroup, groupCtx := errgroup.WithContext(cancellableCtx)
+
+	iter := &MergeIterator[T]{
+		inputs:      make([]chan mergeEntry[T], len(iterators)),
+		errs:        make([]error, len(iterators)),
+		last:        -1,
+		workerGroup: workerGroup,
+		ctx:         cancellableCtx,
+		parentCtx:   ctx,
+		cancel:      cancel,
+		queue: &mergeQueue[T]{
+			entries:    make([]mergeEntry[T], 0, len(iterators)),
+			comparison: comparison,
+		},
+		mergeIteratorOptions: mergeIteratorOptionsWithDefault(opts),
+	}
+
+	for i := range iterators {
+		idx := i
+		iter.inputs[idx] = make(chan mergeEntry[T], iter.buffers)
+
+		workerGroup.Go(func() (err error) {
+			iterator := iterators[idx]
+			output := iter.inputs[idx]
+
+			defer func() {
+				_ = iterator.Close()
+				iter.errs[idx] = err // the error is published to the consumer by closing the channel
+				close(output)
+			}()
+
+			for iterator.Next() {
+				item, itemErr := iterator.Item()
+				if errors.Is(itemErr, io.EOF) {
+					continue
+				}
+
+				select {
+				case <-groupCtx.Done():
+					return groupCtx.Err()
+				case output <- mergeEntry[T]{item: item, err: itemErr, input: idx}:
+				}
+			}
+
+			return iteratorErr(iterator)
+		})
+	}
+
+	return iter
+}
+
+func (m *MergeIterator[T]) Next() bool {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if m.isClosed || m.done || m.err != nil {
+		return false
+	}
+
+	var empty T
+	m.current = empty
+	m.itemErr = nil
+
+	if !m.started {
+		m.started = true
+
+		for i := range m.inputs {
+			if !m.receive(i) {
+				return false
+			}
+		}
+	} else if m.last >= 0 {
+		if !m.receive(m.last) {
+			return false
+		}
+	}
+	m.last = -1
+
+	if len(m.itemErrs) > 0 {
+		m.itemErr = m.itemErrs[0]
+		m.itemErrs = m.itemErrs[1:]
+
+		return true
+	}
+
+	if m.queue.Len() == 0 {
+		m.done = true
+
+		return false
+	}
+
+	entry := heap.Pop(m.queue).(mergeEntry[T])
+	m.current = entry.item
+	m.last = entry.input
+
+	return true
+}
+
+// receive the next item from input i and pushes it onto the merge queue.
+//
+// Item errors received meanwhile are retained, to be delivered first.
+//
+// It returns false if an error interrupted the iteration.
+func (m *MergeIterator[T]) receive(i int) bool {
+	for {
+		select {
+		case entry, ok := <-m.inputs[i]:
+			if !ok {
+				if err := m.errs[i]; err != nil {
+					m.fail(err)
+
+					return false
+				}
+
+				return true
+			}
+
+			if entry.err != nil {
+				m.itemErrs = append(m.itemErrs, entry.err)
+
+				continue
+			}
+
+			heap.Push(m.queue, entry)
+
+			return true
+		case <-m.ctx.Done():
+			m.fail(m.ctx.Err())
+
+			return false
+		}
+	}
+}
+
+// fail stops all workers and retains the first error reported by an input iterator, preferably to
+// a context cancellation triggered by this error.
+func (m *MergeIterator[T]) fail(err error) {
+	m.cancel()
+	m.err = preferErrorOverContext(err, m.workerGroup.Wait())
+}
+
+func (m *MergeIterator[T]) Item() (T, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if !m.started || m.done || m.isClosed || m.err != nil {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return m.current, m.itemErr
+}
+
+// Err returns the first error reported by an input iterator, or the context error if the context is cancelled.
+func (m *MergeIterator[T]) Err() error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	return m.err
+}
+
+// Close stops all pending workers, then waits for them to complete and relinquish their input iterators.
+//
+// It returns the first error reported by an input iterator, if any.
+func (m *MergeIterator[T]) Close() error {
+	m.cancel()
+
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if m.isClosed {
+		return nil
+	}
+	m.isClosed = true
+
+	err := m.workerGroup.Wait()
+	if errors.Is(err, context.Canceled) && m.parentCtx.Err() == nil {
+		// workers have been interrupted by Close(), not by the caller's context
+		return nil
+	}
+
+	return err
+}
+
+func (m *MergeIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](m, m.preallocatedItems)
+}
+
+func (m *MergeIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](m, m.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (m *MergeIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](m)
+}
+
+// Len implements heap.Interface
+func (q mergeQueue[T]) Len() int {
+	return len(q.entries)
+}
+
+// Less implements heap.Interface.
+//
+// Equal items are ordered by input.
+func (q mergeQueue[T]) Less(i, j int) bool {
+	if c := q.comparison(q.entries[i].item, q.entries[j].item); c != 0 {
+		return c < 0
+	}
+
+	return q.entries[i].input < q.entries[j].input
+}
+
+// Swap implements heap.Interface
+func (q mergeQueue[T]) Swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+}
+
+// Push implements heap.Interface
+func (q *mergeQueue[T]) Push(x any) {
+	q.entries = append(q.entries, x.(mergeEntry[T]))
+}
+
+// Pop implements heap.Interface
+func (q *mergeQueue[T]) Pop() any {
+	last := len(q.entries) - 1
+	entry := q.entries[last]
+	q.entries = q.entries[:last]
+
+	return entry
+}
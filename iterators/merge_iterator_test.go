@@ -0,0 +1,171 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/fredbi/go-patterns/sorters"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeIterator(t *testing.T) {
+	byA := func(a, b dummyStruct) int {
+		return sorters.OrderedComparator[int]()(a.A, b.A)
+	}
+
+	sortedInputs := func() []StructIterator[dummyStruct] {
+		return []StructIterator[dummyStruct]{
+			NewSliceIterator([]dummyStruct{{A: 1, B: "a"}, {A: 4, B: "a"}, {A: 7, B: "a"}}),
+			NewSliceIterator([]dummyStruct{{A: 2, B: "b"}, {A: 4, B: "b"}, {A: 8, B: "b"}, {A: 9, B: "b"}}),
+			NewSliceIterator([]dummyStruct{}),
+			NewSliceIterator([]dummyStruct{{A: 0, B: "c"}, {A: 4, B: "c"}}),
+		}
+	}
+	expected := []dummyStruct{
+		{A: 0, B: "c"},
+		{A: 1, B: "a"},
+		{A: 2, B: "b"},
+		{A: 4, B: "a"},
+		{A: 4, B: "b"},
+		{A: 4, B: "c"},
+		{A: 7, B: "a"},
+		{A: 8, B: "b"},
+		{A: 9, B: "b"},
+	}
+
+	t.Run("should Collect merged items", func(t *testing.T) {
+		iterator := NewMergeIterator[dummyStruct](context.Background(), sortedInputs(), byA)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, expected, items)
+	})
+
+	t.Run("should CollectPtr merged items (unbuffered)", func(t *testing.T) {
+		iterator := NewMergeIterator[dummyStruct](context.Background(), sortedInputs(), byA,
+			WithMergeBuffers(0),
+			WithMergePreallocatedItems(20),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, len(expected))
+		require.Equal(t, 20, cap(items))
+		for i, item := range items {
+			require.Equal(t, expected[i], *item)
+		}
+	})
+
+	t.Run("should iterate merged items", func(t *testing.T) {
+		iterator := NewMergeIterator[dummyStruct](context.Background(), sortedInputs(), byA, WithMergeBuffers(10))
+
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+
+		items := make([]dummyStruct, 0, len(expected))
+		for iterator.Next() {
+			item, err := iterator.Item()
+			require.NoError(t, err)
+			items = append(items, item)
+		}
+		require.Equal(t, expected, items)
+
+		_, err = iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Close())
+
+		t.Run("should not error if closed twice", func(t *testing.T) {
+			require.NoError(t, iterator.Close())
+		})
+	})
+
+	t.Run("with no inputs", func(t *testing.T) {
+		iterator := NewMergeIterator[dummyStruct](context.Background(), nil, byA)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Empty(t, items)
+	})
+
+	t.Run("should close all inputs on early Close()", func(t *testing.T) {
+		large := make([]dummyStruct, 1000)
+		trackers := []*closeTracker[dummyStruct]{
+			newCloseTracker[dummyStruct](NewSliceIterator(large)),
+			newCloseTracker[dummyStruct](NewSliceIterator(large)),
+		}
+		iterator := NewMergeIterator[dummyStruct](context.Background(),
+			[]StructIterator[dummyStruct]{trackers[0], trackers[1]},
+			byA,
+		)
+
+		require.True(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+		require.False(t, iterator.Next())
+
+		for _, tracker := range trackers {
+			require.True(t, tracker.isClosed())
+		}
+	})
+
+	t.Run("should propagate input item errors", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in dummyStruct) (dummyStruct, error) {
+			if in.A > 5 {
+				return dummyStruct{}, errTest
+			}
+
+			return in, nil
+		}
+		inputs := sortedInputs()
+		inputs[1] = NewTransformIterator[dummyStruct, dummyStruct](context.Background(), inputs[1], errorer)
+		iterator := NewMergeIterator[dummyStruct](context.Background(), inputs, byA)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Less(t, len(items), len(expected))
+
+		t.Run("should continue after input item errors", func(t *testing.T) {
+			inputs := sortedInputs()
+			inputs[1] = NewTransformIterator[dummyStruct, dummyStruct](context.Background(), inputs[1], errorer)
+			iterator := NewMergeIterator[dummyStruct](context.Background(), inputs, byA)
+
+			items, err := CollectWithPolicy[dummyStruct](iterator, SkipErrors())
+			require.ErrorIs(t, err, errTest)
+			require.Equal(t, []dummyStruct{
+				{A: 0, B: "c"},
+				{A: 1, B: "a"},
+				{A: 2, B: "b"},
+				{A: 4, B: "a"},
+				{A: 4, B: "b"},
+				{A: 4, B: "c"},
+				{A: 7, B: "a"},
+			}, items)
+			require.NoError(t, iterator.Err())
+		})
+	})
+
+	t.Run("should stop on input iterator failure", func(t *testing.T) {
+		errCursor := errors.New("cursor error")
+		rows := newFakeRows([]string{"a", "b"}, []any{3, "d"})
+		rows.err = errCursor
+		inputs := sortedInputs()
+		inputs[2] = NewScanIterator[*fakeRows, dummyStruct](rows)
+		iterator := NewMergeIterator[dummyStruct](context.Background(), inputs, byA)
+
+		_, err := iterator.Collect()
+		require.ErrorIs(t, err, errCursor)
+		require.False(t, iterator.Next())
+		require.ErrorIs(t, iterator.Err(), errCursor)
+	})
+
+	t.Run("should stop on cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		iterator := NewMergeIterator[dummyStruct](ctx, sortedInputs(), byA)
+
+		cancel()
+		_, err := iterator.Collect()
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,129 @@
+package iterators
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	_ Observer   = &Metrics{}
+	_ expvar.Var = &Metrics{}
+)
+
+type (
+	// Metrics is a built-in Observer that counts items and measures latencies.
+	//
+	// Metrics may be shared by several iterators. It implements expvar.Var, so it may be exported with:
+	//
+	//	metrics := iterators.NewMetrics()
+	//	expvar.Publish("db_cursors", metrics)
+	Metrics struct {
+		items          atomic.Int64
+		itemErrors     atomic.Int64
+		closed         atomic.Int64
+		failed         atomic.Int64
+		firstItems     atomic.Int64
+		firstItemTotal atomic.Int64
+		itemTotal      atomic.Int64
+		itemMax        atomic.Int64
+		openTotal      atomic.Int64
+		openMax        atomic.Int64
+	}
+
+	// MetricsSnapshot holds the values of Metrics at some point in time.
+	//
+	// Durations are expressed in nanoseconds when exported as JSON.
+	MetricsSnapshot struct {
+		// Items is the number of items successfully retrieved.
+		Items int64 `json:"items"`
+
+		// ItemErrors is the number of errors returned by Item().
+		ItemErrors int64 `json:"item_errors"`
+
+		// Closed is the number of iterators closed.
+		Closed int64 `json:"closed"`
+
+		// Failed is the number of iterators closed with an error.
+		Failed int64 `json:"failed"`
+
+		AvgTimeToFirstItem time.Duration `json:"avg_time_to_first_item"`
+		AvgItemLatency     time.Duration `json:"avg_item_latency"`
+		MaxItemLatency     time.Duration `json:"max_item_latency"`
+		AvgOpenDuration    time.Duration `json:"avg_open_duration"`
+		MaxOpenDuration    time.Duration `json:"max_open_duration"`
+	}
+)
+
+// NewMetrics builds a new Metrics observer.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+func (m *Metrics) OnFirstItem(elapsed time.Duration) {
+	m.firstItems.Add(1)
+	m.firstItemTotal.Add(int64(elapsed))
+}
+
+func (m *Metrics) OnItem(latency time.Duration) {
+	m.items.Add(1)
+	m.itemTotal.Add(int64(latency))
+	storeMax(&m.itemMax, int64(latency))
+}
+
+func (m *Metrics) OnItemError(_ error) {
+	m.itemErrors.Add(1)
+}
+
+func (m *Metrics) OnClose(elapsed time.Duration, err error) {
+	m.closed.Add(1)
+	if err != nil {
+		m.failed.Add(1)
+	}
+
+	m.openTotal.Add(int64(elapsed))
+	storeMax(&m.openMax, int64(elapsed))
+}
+
+// Snapshot returns the current values of the metrics.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	items := m.items.Load()
+	closed := m.closed.Load()
+
+	return MetricsSnapshot{
+		Items:              items,
+		ItemErrors:         m.itemErrors.Load(),
+		Closed:             closed,
+		Failed:             m.failed.Load(),
+		AvgTimeToFirstItem: average(m.firstItemTotal.Load(), m.firstItems.Load()),
+		AvgItemLatency:     average(m.itemTotal.Load(), items),
+		MaxItemLatency:     time.Duration(m.itemMax.Load()),
+		AvgOpenDuration:    average(m.openTotal.Load(), closed),
+		MaxOpenDuration:    time.Duration(m.openMax.Load()),
+	}
+}
+
+// String exports the metrics as JSON, to implement expvar.Var.
+func (m *Metrics) String() string {
+	buf, _ := json.Marshal(m.Snapshot())
+
+	return string(buf)
+}
+
+func average(total, count int64) time.Duration {
+	if count == 0 {
+		return 0
+	}
+
+	return time.Duration(total / count)
+}
+
+func storeMax(current *atomic.Int64, value int64) {
+	for {
+		previous := current.Load()
+		if value <= previous || current.CompareAndSwap(previous, value) {
+			return
+		}
+	}
+}
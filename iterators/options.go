@@ -1,5 +1,11 @@
 package iterators
 
+import (
+	"bufio"
+	"context"
+	"time"
+)
+
 type (
 	// RowsIteratorOption provides options to the RowsIterator
 	RowsIteratorOption func(*rowsIteratorOptions)
@@ -7,20 +13,90 @@ type (
 	// ChanIteratorOption provides options to the ChanIterator
 	ChanIteratorOption func(*chanIteratorOptions)
 
+	// MergeIteratorOption provides options to the MergeIterator
+	MergeIteratorOption func(*mergeIteratorOptions)
+
+	// PagedIteratorOption provides options to the PagedIterator
+	PagedIteratorOption func(*pagedIteratorOptions)
+
+	// ZipIteratorOption provides options to the ZipIterator
+	ZipIteratorOption func(*zipIteratorOptions)
+
+	// DistinctIteratorOption provides options to the DistinctIterator
+	DistinctIteratorOption func(*distinctIteratorOptions)
+
+	// JSONLinesIteratorOption provides options to the JSONLinesIterator
+	JSONLinesIteratorOption func(*jsonLinesIteratorOptions)
+
+	// CSVOption provides options to the CSVIterator and to WriteCSV
+	CSVOption func(*csvOptions)
+
+	// TeeOption provides options to the TeeIterator
+	TeeOption func(*teeOptions)
+
 	rowsIteratorOptions struct {
 		preallocatedItems int
+		transformWorkers  int
+		transformOrdered  bool
+		ctx               context.Context
 	}
 
 	chanIteratorOptions struct {
 		*rowsIteratorOptions
 
 		fanInBuffers int
+		ordered      bool
+	}
+
+	mergeIteratorOptions struct {
+		*rowsIteratorOptions
+
+		buffers int
+	}
+
+	pagedIteratorOptions struct {
+		*rowsIteratorOptions
+
+		pageSize   int
+		startAfter []any
+	}
+
+	zipIteratorOptions struct {
+		*rowsIteratorOptions
+
+		longest bool
+	}
+
+	distinctIteratorOptions struct {
+		*rowsIteratorOptions
+
+		maxKeys int
+	}
+
+	jsonLinesIteratorOptions struct {
+		*rowsIteratorOptions
+
+		maxLineSize int
+	}
+
+	csvOptions struct {
+		*rowsIteratorOptions
+
+		comma      rune
+		timeLayout string
+	}
+
+	teeOptions struct {
+		*rowsIteratorOptions
+
+		failLagging bool
 	}
 )
 
 func rowsIteratorOptionsWithDefault(opts []RowsIteratorOption) *rowsIteratorOptions {
 	options := &rowsIteratorOptions{
 		preallocatedItems: 1000,
+		transformOrdered:  true,
 	}
 
 	for _, apply := range opts {
@@ -40,6 +116,53 @@ func WithRowsPreallocatedItems(n int) RowsIteratorOption {
 	}
 }
 
+// WithTransformWorkers runs the transformer of a TransformIterator on a pool of n parallel workers.
+//
+// This option only applies to the TransformIterator.
+//
+// The default value is 0, meaning that items are transformed synchronously at every call to Item().
+func WithTransformWorkers(n int) RowsIteratorOption {
+	return func(o *rowsIteratorOptions) {
+		o.transformWorkers = n
+	}
+}
+
+// WithTransformOrdered tells a TransformIterator running parallel workers to deliver
+// transformed items in the order of the source iterator.
+//
+// When disabled, items are delivered as soon as they are transformed.
+//
+// This option only applies to the TransformIterator when used with WithTransformWorkers.
+//
+// The default value is true.
+func WithTransformOrdered(enabled bool) RowsIteratorOption {
+	return func(o *rowsIteratorOptions) {
+		o.transformOrdered = enabled
+	}
+}
+
+// WithContext makes a cursor iterator stop when the context is cancelled: Next() returns false,
+// the cursor is closed and the context error is reported by Err() and Collect().
+//
+// This option only applies to the RowsIterator, the ScanIterator and the PgxIterator.
+// The TransformIterator always watches the context passed to its constructor.
+//
+// By default, no context is watched.
+func WithContext(ctx context.Context) RowsIteratorOption {
+	return func(o *rowsIteratorOptions) {
+		o.ctx = ctx
+	}
+}
+
+// ctxErr returns the error of the context set by WithContext, if any.
+func (o *rowsIteratorOptions) ctxErr() error {
+	if o.ctx == nil {
+		return nil
+	}
+
+	return o.ctx.Err()
+}
+
 func chanIteratorOptionsWithDefault(opts []ChanIteratorOption) *chanIteratorOptions {
 	options := &chanIteratorOptions{
 		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
@@ -68,3 +191,236 @@ func WithChanFanInBuffers(n int) ChanIteratorOption {
 		o.fanInBuffers = n
 	}
 }
+
+// WithChanOrdered delivers items in the order of the input iterators:
+// all items from the first input are delivered first, then the items from the second input, etc.
+//
+// Inputs are still fetched concurrently, each input being prefetched into its own buffer, which size is set
+// by WithChanFanInBuffers.
+//
+// The order is guaranteed only when the ChanIterator is consumed by a single goroutine.
+func WithChanOrdered() ChanIteratorOption {
+	return func(o *chanIteratorOptions) {
+		o.ordered = true
+	}
+}
+
+func mergeIteratorOptionsWithDefault(opts []MergeIteratorOption) *mergeIteratorOptions {
+	options := &mergeIteratorOptions{
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
+		buffers:             1,
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+
+	return options
+}
+
+// WithMergePreallocatedItems preallocate n items in the returned slice when
+// using the Collect and CollectPtr methods.
+func WithMergePreallocatedItems(n int) MergeIteratorOption {
+	return func(o *mergeIteratorOptions) {
+		o.preallocatedItems = n
+	}
+}
+
+// WithMergeBuffers sets the number of items pre-fetched from every input iterator.
+//
+// The default value is 1.
+func WithMergeBuffers(n int) MergeIteratorOption {
+	return func(o *mergeIteratorOptions) {
+		o.buffers = n
+	}
+}
+
+func pagedIteratorOptionsWithDefault(opts []PagedIteratorOption) *pagedIteratorOptions {
+	options := &pagedIteratorOptions{
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+
+	return options
+}
+
+// WithPagedPreallocatedItems preallocate n items in the returned slice when
+// using the Collect and CollectPtr methods.
+func WithPagedPreallocatedItems(n int) PagedIteratorOption {
+	return func(o *pagedIteratorOptions) {
+		o.preallocatedItems = n
+	}
+}
+
+// WithPagedStartAfter resumes the iteration after the given key values,
+// e.g. as returned by LastKey() from a previous iteration.
+func WithPagedStartAfter(key []any) PagedIteratorOption {
+	return func(o *pagedIteratorOptions) {
+		o.startAfter = key
+	}
+}
+
+func zipIteratorOptionsWithDefault(opts []ZipIteratorOption) *zipIteratorOptions {
+	options := &zipIteratorOptions{
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+
+	return options
+}
+
+// WithZipPreallocatedItems preallocate n items in the returned slice when
+// using the Collect and CollectPtr methods.
+func WithZipPreallocatedItems(n int) ZipIteratorOption {
+	return func(o *zipIteratorOptions) {
+		o.preallocatedItems = n
+	}
+}
+
+// WithZipLongest iterates until both inputs are exhausted.
+//
+// Pairs delivered after the shortest input is exhausted are flagged with a missing left or right item.
+//
+// By default, the iteration stops as soon as the shortest input is exhausted.
+func WithZipLongest() ZipIteratorOption {
+	return func(o *zipIteratorOptions) {
+		o.longest = true
+	}
+}
+
+func distinctIteratorOptionsWithDefault(opts []DistinctIteratorOption) *distinctIteratorOptions {
+	options := &distinctIteratorOptions{
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+
+	return options
+}
+
+// WithDistinctPreallocatedItems preallocate n items in the returned slice when
+// using the Collect and CollectPtr methods.
+func WithDistinctPreallocatedItems(n int) DistinctIteratorOption {
+	return func(o *distinctIteratorOptions) {
+		o.preallocatedItems = n
+	}
+}
+
+// WithDistinctMaxKeys bounds the memory used by a DistinctIterator to n keys.
+//
+// When the bound is reached, the least recently seen key is evicted: duplicates are then only removed
+// within a window of n distinct keys.
+//
+// The default value is 0, meaning that all keys are retained.
+func WithDistinctMaxKeys(n int) DistinctIteratorOption {
+	return func(o *distinctIteratorOptions) {
+		o.maxKeys = n
+	}
+}
+
+func jsonLinesIteratorOptionsWithDefault(opts []JSONLinesIteratorOption) *jsonLinesIteratorOptions {
+	options := &jsonLinesIteratorOptions{
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
+		maxLineSize:         bufio.MaxScanTokenSize,
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+
+	return options
+}
+
+// WithJSONLinesPreallocatedItems preallocate n items in the returned slice when
+// using the Collect and CollectPtr methods.
+func WithJSONLinesPreallocatedItems(n int) JSONLinesIteratorOption {
+	return func(o *jsonLinesIteratorOptions) {
+		o.preallocatedItems = n
+	}
+}
+
+// WithJSONLinesMaxLineSize sets the maximum size in bytes of a line, not counting the line terminator.
+//
+// The iteration is interrupted when a longer line is read.
+//
+// The default value is bufio.MaxScanTokenSize (64 KB). Values lower than 1 are ignored.
+func WithJSONLinesMaxLineSize(n int) JSONLinesIteratorOption {
+	return func(o *jsonLinesIteratorOptions) {
+		if n < 1 {
+			return
+		}
+
+		o.maxLineSize = n
+	}
+}
+
+func csvOptionsWithDefault(opts []CSVOption) *csvOptions {
+	options := &csvOptions{
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
+		comma:               ',',
+		timeLayout:          time.RFC3339,
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+
+	return options
+}
+
+// WithCSVPreallocatedItems preallocate n items in the returned slice when
+// using the Collect and CollectPtr methods.
+func WithCSVPreallocatedItems(n int) CSVOption {
+	return func(o *csvOptions) {
+		o.preallocatedItems = n
+	}
+}
+
+// WithCSVComma sets the field delimiter, e.g. ';' or '\t'.
+//
+// The default value is ','.
+func WithCSVComma(comma rune) CSVOption {
+	return func(o *csvOptions) {
+		o.comma = comma
+	}
+}
+
+// WithCSVTimeLayout sets the layout used to parse and format time.Time values.
+//
+// The default value is time.RFC3339.
+func WithCSVTimeLayout(layout string) CSVOption {
+	return func(o *csvOptions) {
+		o.timeLayout = layout
+	}
+}
+
+func teeOptionsWithDefault(opts []TeeOption) *teeOptions {
+	options := &teeOptions{
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(nil),
+	}
+	for _, apply := range opts {
+		apply(options)
+	}
+
+	return options
+}
+
+// WithTeePreallocatedItems preallocate n items in the returned slice when
+// using the Collect() method of a TeeIterator.
+func WithTeePreallocatedItems(n int) TeeOption {
+	return func(o *teeOptions) {
+		o.preallocatedItems = n
+	}
+}
+
+// WithTeeFailLagging detaches the branches lagging behind by more than the buffer size,
+// so the other branches may proceed. A detached branch stops with ErrTeeLagging.
+//
+// By default, the fastest branches block until the lagging branches catch up.
+func WithTeeFailLagging() TeeOption {
+	return func(o *teeOptions) {
+		o.failLagging = true
+	}
+}
@@ -0,0 +1,203 @@
+package iterators
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ StructIterator[dummy] = &PagedIterator[dummy]{}
+
+type (
+	// KeyExtractor extracts the values of the pagination key from an item.
+	//
+	// Values must be returned in the same order as the key columns.
+	KeyExtractor[T any] func(T) []any
+
+	// PagedIterator iterates over the result of a SQL query, fetching rows by pages using keyset pagination.
+	//
+	// Unlike the RowsIterator, no cursor is held open while iterating: every page is fetched with a
+	// short-lived query of the form:
+	//
+	//	SELECT ... WHERE (k1, k2) > ($1, $2) ORDER BY k1, k2 LIMIT n
+	//
+	// The key columns must uniquely identify rows. Rows are iterated in ascending key order.
+	//
+	// LastKey() returns the key of the last item delivered, so an interrupted iteration may be resumed
+	// later on using WithPagedStartAfter.
+	//
+	// Notice that the paged iterator is not goroutine-safe and should not be iterated concurrently.
+	PagedIterator[T any] struct {
+		ctx        context.Context
+		db         sqlx.QueryerContext
+		query      sq.SelectBuilder
+		keyColumns []string
+		keyFunc    KeyExtractor[T]
+		lastKey    []any
+		page       []T
+		index      int
+		exhausted  bool
+		isClosed   bool
+		current    T
+		err        error
+		hasCurrent bool
+
+		*pagedIteratorOptions
+	}
+)
+
+// NewPagedIterator makes a PagedIterator[T] producing items of type T from a query executed by pages of pageSize rows.
+//
+// The query must not specify any ORDER BY or LIMIT clause: these are added by the iterator, using keyColumns.
+// The placeholder format of the query builder (e.g. sq.Dollar for postgres) is used to generate the queries.
+//
+// Rows are scanned into structs of type T using sqlx.
+func NewPagedIterator[T any](ctx context.Context, db sqlx.QueryerContext, query sq.SelectBuilder, keyColumns []string, keyFunc KeyExtractor[T], pageSize int, opts ...PagedIteratorOption) *PagedIterator[T] {
+	options := pagedIteratorOptionsWithDefault(opts)
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	options.pageSize = pageSize
+
+	return &PagedIterator[T]{
+		ctx:                  ctx,
+		db:                   db,
+		query:                query,
+		keyColumns:           keyColumns,
+		keyFunc:              keyFunc,
+		lastKey:              options.startAfter,
+		pagedIteratorOptions: options,
+	}
+}
+
+// LastKey returns the key values of the last item delivered by the iterator.
+//
+// Before any item is delivered, this is the key set by WithPagedStartAfter, if any.
+func (pi *PagedIterator[T]) LastKey() []any {
+	return pi.lastKey
+}
+
+func (pi *PagedIterator[T]) Next() bool {
+	var empty T
+	pi.current = empty
+	pi.hasCurrent = false
+
+	if pi.isClosed || pi.err != nil {
+		return false
+	}
+
+	if pi.index+1 >= len(pi.page) {
+		if pi.exhausted {
+			return false
+		}
+
+		if err := pi.fetchPage(); err != nil {
+			pi.err = err
+
+			return false
+		}
+
+		if len(pi.page) == 0 {
+			return false
+		}
+	} else {
+		pi.index++
+	}
+
+	pi.current = pi.page[pi.index]
+	pi.lastKey = pi.keyFunc(pi.current)
+	pi.hasCurrent = true
+
+	return true
+}
+
+// fetchPage runs the query for the next page and collects all rows.
+func (pi *PagedIterator[T]) fetchPage() error {
+	query, args, err := pi.pageQuery().ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := pi.db.QueryxContext(pi.ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	page, err := NewSqlxIterator[T](rows, WithRowsPreallocatedItems(pi.pageSize)).Collect()
+	if err != nil {
+		return err
+	}
+
+	pi.page = page
+	pi.index = 0
+	pi.exhausted = len(page) < pi.pageSize
+
+	return nil
+}
+
+// pageQuery builds the query for the next page, starting after the last seen key.
+func (pi *PagedIterator[T]) pageQuery() sq.SelectBuilder {
+	query := pi.query.
+		OrderBy(pi.keyColumns...).
+		Limit(uint64(pi.pageSize))
+
+	if len(pi.lastKey) == 0 {
+		return query
+	}
+
+	return query.Where(keysetPredicate(pi.keyColumns), pi.lastKey...)
+}
+
+// keysetPredicate builds a row comparison such as "(k1, k2) > (?, ?)".
+func keysetPredicate(keyColumns []string) string {
+	if len(keyColumns) == 1 {
+		return fmt.Sprintf("%s > ?", keyColumns[0])
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keyColumns)), ", ")
+
+	return fmt.Sprintf("(%s) > (%s)", strings.Join(keyColumns, ", "), placeholders)
+}
+
+func (pi *PagedIterator[T]) Item() (T, error) {
+	if !pi.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return pi.current, nil
+}
+
+// Err returns the error that interrupted the iteration while fetching a page, if any.
+func (pi *PagedIterator[T]) Err() error {
+	return pi.err
+}
+
+// Close the iterator. Since no cursor is held between pages, this only stops the iteration.
+func (pi *PagedIterator[T]) Close() error {
+	pi.isClosed = true
+	pi.page = nil
+
+	return nil
+}
+
+func (pi *PagedIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](pi, pi.preallocatedItems)
+}
+
+func (pi *PagedIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](pi, pi.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (pi *PagedIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](pi)
+}
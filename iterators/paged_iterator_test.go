@@ -0,0 +1,115 @@
+package iterators
+
+import (
+	"context"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/fredbi/go-patterns/iterators/internal/testdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagedIteratorQuery(t *testing.T) {
+	byA := func(in testdb.DummyRow) []any {
+		return []any{in.A}
+	}
+	query := sq.Select("a", "b").From("dummy").Where(sq.Eq{"b": "x"}).PlaceholderFormat(sq.Dollar)
+
+	t.Run("should build first page query", func(t *testing.T) {
+		iterator := NewPagedIterator[testdb.DummyRow](context.Background(), nil, query, []string{"a"}, byA, 10)
+
+		sql, args, err := iterator.pageQuery().ToSql()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT a, b FROM dummy WHERE b = $1 ORDER BY a LIMIT 10", sql)
+		require.Equal(t, []any{"x"}, args)
+	})
+
+	t.Run("should build next page query with a single key", func(t *testing.T) {
+		iterator := NewPagedIterator[testdb.DummyRow](context.Background(), nil, query, []string{"a"}, byA, 10,
+			WithPagedStartAfter([]any{5}),
+		)
+
+		sql, args, err := iterator.pageQuery().ToSql()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT a, b FROM dummy WHERE b = $1 AND a > $2 ORDER BY a LIMIT 10", sql)
+		require.Equal(t, []any{"x", 5}, args)
+		require.Equal(t, []any{5}, iterator.LastKey())
+	})
+
+	t.Run("should build next page query with a compound key", func(t *testing.T) {
+		iterator := NewPagedIterator[testdb.DummyRow](context.Background(), nil, query, []string{"a", "b"},
+			func(in testdb.DummyRow) []any {
+				return []any{in.A, in.B}
+			},
+			10,
+			WithPagedStartAfter([]any{5, "y"}),
+		)
+
+		sql, args, err := iterator.pageQuery().ToSql()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT a, b FROM dummy WHERE b = $1 AND (a, b) > ($2, $3) ORDER BY a, b LIMIT 10", sql)
+		require.Equal(t, []any{"x", 5, "y"}, args)
+	})
+}
+
+func TestPagedIterator(t *testing.T) {
+	const rows = 25
+	dbName := testdb.UniqueDBName()
+	db, err := testdb.CreateDBWithRows(dbName, rows)
+	require.NoError(t, err)
+
+	byA := func(in testdb.DummyRow) []any {
+		return []any{in.A}
+	}
+	query := sq.Select("a", "b").From("dummy").PlaceholderFormat(sq.Dollar)
+
+	t.Run("should Collect all rows by pages", func(t *testing.T) {
+		iterator := NewPagedIterator[testdb.DummyRow](context.Background(), db, query, []string{"a"}, byA, 10)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, rows)
+		for i, item := range items {
+			require.Equal(t, i+1, item.A)
+		}
+	})
+
+	t.Run("should CollectPtr all rows with exact pages", func(t *testing.T) {
+		iterator := NewPagedIterator[testdb.DummyRow](context.Background(), db, query, []string{"a"}, byA, 5,
+			WithPagedPreallocatedItems(30),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, rows)
+		require.Equal(t, 30, cap(items))
+	})
+
+	t.Run("should resume after last key", func(t *testing.T) {
+		iterator := NewPagedIterator[testdb.DummyRow](context.Background(), db, query, []string{"a"}, byA, 10)
+
+		for i := 0; i < 12; i++ {
+			require.True(t, iterator.Next())
+			_, err := iterator.Item()
+			require.NoError(t, err)
+		}
+		require.NoError(t, iterator.Close())
+		require.Equal(t, []any{12}, iterator.LastKey())
+
+		resumed := NewPagedIterator[testdb.DummyRow](context.Background(), db, query, []string{"a"}, byA, 10,
+			WithPagedStartAfter(iterator.LastKey()),
+		)
+		items, err := resumed.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, rows-12)
+		require.Equal(t, 13, items[0].A)
+	})
+
+	t.Run("should error on invalid query", func(t *testing.T) {
+		invalid := sq.Select("z").From("dummy").PlaceholderFormat(sq.Dollar)
+		iterator := NewPagedIterator[testdb.DummyRow](context.Background(), db, invalid, []string{"a"}, byA, 10)
+
+		_, err := iterator.Collect()
+		require.Error(t, err)
+	})
+}
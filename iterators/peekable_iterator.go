@@ -0,0 +1,189 @@
+package iterators
+
+import (
+	"io"
+	"iter"
+	"sync"
+)
+
+var _ StructIterator[dummy] = &PeekableIterator[dummy]{}
+
+type (
+	// PeekableIterator wraps any iterator with lookahead and push-back capabilities.
+	//
+	// Peek() and PeekN() retrieve the next items without consuming them. Unread() pushes back an item,
+	// which is delivered by the next call to Next().
+	//
+	// Items retrieved in advance are buffered together with the error returned by the underlying Item() method, if any.
+	//
+	// Like the SliceIterator, the peekable iterator is goroutine-safe.
+	PeekableIterator[T any] struct {
+		source     StructIterator[T]
+		ahead      []peeked[T]
+		exhausted  bool
+		mx         sync.Mutex
+		current    peeked[T]
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+
+	peeked[T any] struct {
+		item T
+		err  error
+	}
+)
+
+// Peekable makes a PeekableIterator[T] from any iterator.
+func Peekable[T any](iterator StructIterator[T], opts ...RowsIteratorOption) *PeekableIterator[T] {
+	return &PeekableIterator[T]{
+		source:              iterator,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (pk *PeekableIterator[T]) Next() bool {
+	pk.mx.Lock()
+	defer pk.mx.Unlock()
+
+	pk.current = peeked[T]{}
+	pk.hasCurrent = false
+
+	if len(pk.ahead) == 0 && !pk.fill(1) {
+		return false
+	}
+
+	pk.current = pk.ahead[0]
+	pk.ahead = pk.ahead[1:]
+	pk.hasCurrent = true
+
+	return true
+}
+
+func (pk *PeekableIterator[T]) Item() (T, error) {
+	pk.mx.Lock()
+	defer pk.mx.Unlock()
+
+	if !pk.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return pk.current.item, pk.current.err
+}
+
+// Peek returns the next item without consuming it.
+//
+// It returns io.EOF if there is no next item.
+func (pk *PeekableIterator[T]) Peek() (T, error) {
+	pk.mx.Lock()
+	defer pk.mx.Unlock()
+
+	if !pk.fill(1) {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return pk.ahead[0].item, pk.ahead[0].err
+}
+
+// PeekN returns the next n items without consuming them.
+//
+// Fewer items are returned if the iterator is exhausted before n items are available.
+// If one of these items comes with an error, the items before it are returned together with this error.
+//
+// An empty slice is returned if n is lower than 1.
+func (pk *PeekableIterator[T]) PeekN(n int) ([]T, error) {
+	if n < 1 {
+		return []T{}, nil
+	}
+
+	pk.mx.Lock()
+	defer pk.mx.Unlock()
+
+	pk.fill(n)
+
+	items := make([]T, 0, min(n, len(pk.ahead)))
+	for _, next := range pk.ahead[:min(n, len(pk.ahead))] {
+		if next.err != nil {
+			return items, next.err
+		}
+
+		items = append(items, next.item)
+	}
+
+	return items, nil
+}
+
+// Unread pushes back an item, which is delivered by the next call to Next().
+//
+// Items pushed back are delivered in the reverse order of the calls to Unread().
+func (pk *PeekableIterator[T]) Unread(item T) {
+	pk.mx.Lock()
+	defer pk.mx.Unlock()
+
+	pk.ahead = append([]peeked[T]{{item: item}}, pk.ahead...)
+}
+
+// fill retrieves items in advance from the underlying iterator, until n items are buffered.
+//
+// It returns false if fewer than n items are available.
+func (pk *PeekableIterator[T]) fill(n int) bool {
+	for len(pk.ahead) < n {
+		if pk.exhausted || !pk.source.Next() {
+			pk.exhausted = true
+
+			return false
+		}
+
+		item, err := pk.source.Item()
+		if isEndOfStream(err) {
+			pk.exhausted = true
+
+			return false
+		}
+
+		pk.ahead = append(pk.ahead, peeked[T]{item: item, err: err})
+	}
+
+	return true
+}
+
+// Err returns the error reported by the underlying iterator, once all items retrieved in advance have been consumed.
+func (pk *PeekableIterator[T]) Err() error {
+	pk.mx.Lock()
+	defer pk.mx.Unlock()
+
+	if len(pk.ahead) > 0 {
+		return nil
+	}
+
+	return iteratorErr(pk.source)
+}
+
+// Close the underlying iterator. Items retrieved in advance are discarded.
+func (pk *PeekableIterator[T]) Close() error {
+	pk.mx.Lock()
+	defer pk.mx.Unlock()
+
+	pk.ahead = nil
+
+	return pk.source.Close()
+}
+
+func (pk *PeekableIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](pk, pk.preallocatedItems)
+}
+
+func (pk *PeekableIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](pk, pk.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (pk *PeekableIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](pk)
+}
@@ -0,0 +1,175 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeekableIterator(t *testing.T) {
+	t.Run("should Peek without consuming", func(t *testing.T) {
+		iterator := Peekable[int](NewSliceIterator(intSlice(3)))
+
+		next, err := iterator.Peek()
+		require.NoError(t, err)
+		require.Equal(t, 1, next)
+
+		require.True(t, iterator.Next())
+		item, err := iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, 1, item)
+
+		next, err = iterator.Peek()
+		require.NoError(t, err)
+		require.Equal(t, 2, next)
+
+		// the current item is not altered by Peek
+		item, err = iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, 1, item)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 3}, items)
+	})
+
+	t.Run("should PeekN items", func(t *testing.T) {
+		iterator := Peekable[int](NewSliceIterator(intSlice(3)))
+
+		items, err := iterator.PeekN(2)
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+
+		items, err = iterator.PeekN(5)
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3}, items)
+
+		for _, n := range []int{0, -1} {
+			items, err = iterator.PeekN(n)
+			require.NoError(t, err)
+			require.Empty(t, items)
+		}
+
+		all, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+	})
+
+	t.Run("should Peek io.EOF at the end", func(t *testing.T) {
+		iterator := Peekable[int](NewSliceIterator([]int{1}))
+
+		require.True(t, iterator.Next())
+		_, err := iterator.Peek()
+		require.ErrorIs(t, err, io.EOF)
+		require.False(t, iterator.Next())
+		_, err = iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("should deliver unread items first", func(t *testing.T) {
+		iterator := Peekable[int](NewSliceIterator(intSlice(3)))
+
+		require.True(t, iterator.Next())
+		first, err := iterator.Item()
+		require.NoError(t, err)
+
+		iterator.Unread(first)
+		iterator.Unread(0)
+
+		next, err := iterator.Peek()
+		require.NoError(t, err)
+		require.Equal(t, 0, next)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 1, 2, 3}, items)
+	})
+
+	t.Run("should report item errors from PeekN", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in == 2 {
+				return 0, errTest
+			}
+
+			return in, nil
+		}
+		iterator := Peekable[int](NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(3)), errorer))
+
+		items, err := iterator.PeekN(3)
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, []int{1}, items)
+
+		require.True(t, iterator.Next())
+		require.True(t, iterator.Next())
+		_, err = iterator.Item()
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("should report the source error once buffered items are consumed", func(t *testing.T) {
+		errTest := errors.New("test error")
+		rows := newFakeRows([]string{"id"}, []any{1})
+		rows.err = errTest
+		iterator := Peekable[int](NewScanIterator[*fakeRows, int](rows))
+
+		items, err := iterator.PeekN(2)
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, items)
+		require.NoError(t, iterator.Err())
+
+		require.True(t, iterator.Next())
+		require.False(t, iterator.Next())
+		require.ErrorIs(t, iterator.Err(), errTest)
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		iterator := Peekable[int](newEOFTerminated[int](NewSliceIterator(intSlice(3))))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, intSlice(3), items)
+
+		t.Run("with a ChanIterator source", func(t *testing.T) {
+			for range 100 {
+				inputs := []StructIterator[int]{NewSliceIterator(intSlice(5)), NewSliceIterator(intSlice(5))}
+				iterator := Peekable[int](NewChanIterator[int](context.Background(), inputs))
+
+				items, err := iterator.Collect()
+				require.NoError(t, err)
+				require.Len(t, items, 10)
+			}
+		})
+	})
+
+	t.Run("should close the source", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(3)))
+		iterator := Peekable[int](tracker)
+
+		_, err := iterator.PeekN(2)
+		require.NoError(t, err)
+		require.NoError(t, iterator.Close())
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should be goroutine-safe", func(t *testing.T) {
+		iterator := Peekable[int](NewSliceIterator(make([]int, 100)))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for iterator.Next() {
+					_, _ = iterator.Peek()
+					_, _ = iterator.Item()
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
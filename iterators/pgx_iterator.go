@@ -0,0 +1,111 @@
+package iterators
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var _ StructIterator[dummy] = &PgxIterator[dummy]{}
+
+// PgxIterator transforms a native github.com/jackc/pgx/v5.Rows cursor into a StructIterator with target type T.
+//
+// Rows are mapped to T using the pgx row mapping functions, without the database/sql layer.
+// This allows for pgx-specific types such as arrays, JSONB or numeric to be scanned natively.
+//
+// The cursor error is checked when Next() returns false: if the iteration ended prematurely,
+// the error is reported by Err().
+//
+// Notice that the pgx iterator is not goroutine-safe and should not be iterated concurrently.
+type PgxIterator[T any] struct {
+	rows     pgx.Rows
+	mapper   pgx.RowToFunc[T]
+	mx       sync.Mutex
+	isClosed bool
+	err      error
+
+	*rowsIteratorOptions
+}
+
+// NewPgxIterator makes a PgxIterator[T] producing items of type T from a pgx.Rows cursor.
+//
+// Rows are mapped to the fields of struct T by column name, using pgx.RowToStructByName.
+// Like with pgx, fields are matched using the "db" struct tag, or the field name.
+func NewPgxIterator[T any](rows pgx.Rows, opts ...RowsIteratorOption) *PgxIterator[T] {
+	return NewPgxIteratorFunc[T](rows, pgx.RowToStructByName[T], opts...)
+}
+
+// NewPgxIteratorFunc makes a PgxIterator[T] producing items of type T from a pgx.Rows cursor,
+// using a custom row mapping function.
+//
+// Mapping functions provided by pgx may be used, e.g. pgx.RowTo[T] to scan a single column,
+// or pgx.RowToStructByNameLax[T] to tolerate missing columns.
+func NewPgxIteratorFunc[T any](rows pgx.Rows, mapper pgx.RowToFunc[T], opts ...RowsIteratorOption) *PgxIterator[T] {
+	return &PgxIterator[T]{
+		rows:                rows,
+		mapper:              mapper,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+// Close the cursor.
+//
+// It returns the cursor error, if any.
+func (pi *PgxIterator[T]) Close() error {
+	pi.mx.Lock()
+	defer pi.mx.Unlock()
+
+	if pi.isClosed {
+		return nil
+	}
+	pi.isClosed = true
+	pi.rows.Close()
+
+	return pi.rows.Err()
+}
+
+func (pi *PgxIterator[T]) Next() bool {
+	if pi.err != nil {
+		return false
+	}
+
+	if err := pi.ctxErr(); err != nil {
+		pi.err = err
+		_ = pi.Close()
+
+		return false
+	}
+
+	if pi.rows.Next() {
+		return true
+	}
+
+	pi.err = pi.rows.Err()
+
+	return false
+}
+
+// Err returns the cursor error or the context error that interrupted the iteration, if any.
+func (pi *PgxIterator[T]) Err() error {
+	return pi.err
+}
+
+func (pi *PgxIterator[T]) Item() (T, error) {
+	return pi.mapper(pi.rows)
+}
+
+func (pi *PgxIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](pi, pi.preallocatedItems)
+}
+
+func (pi *PgxIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](pi, pi.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (pi *PgxIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](pi)
+}
@@ -0,0 +1,174 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fredbi/go-patterns/iterators/internal/testdb"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+var _ pgx.Rows = &fakePgxRows{}
+
+// fakePgxRows mimics a pgx.Rows cursor over in-memory values.
+type fakePgxRows struct {
+	*fakeRows
+}
+
+func newFakePgxRows(columns []string, values ...[]any) *fakePgxRows {
+	return &fakePgxRows{fakeRows: newFakeRows(columns, values...)}
+}
+
+func (r *fakePgxRows) Close() {
+	_ = r.fakeRows.Close()
+}
+
+func (r *fakePgxRows) CommandTag() pgconn.CommandTag {
+	return pgconn.CommandTag{}
+}
+
+func (r *fakePgxRows) FieldDescriptions() []pgconn.FieldDescription {
+	fields := make([]pgconn.FieldDescription, 0, len(r.columns))
+	for _, column := range r.columns {
+		fields = append(fields, pgconn.FieldDescription{Name: column})
+	}
+
+	return fields
+}
+
+func (r *fakePgxRows) Scan(dest ...any) error {
+	if len(dest) == 1 {
+		if scanner, ok := dest[0].(pgx.RowScanner); ok {
+			return scanner.ScanRow(r)
+		}
+	}
+
+	return r.fakeRows.Scan(dest...)
+}
+
+func (r *fakePgxRows) Values() ([]any, error) {
+	return r.values[r.index], nil
+}
+
+func (r *fakePgxRows) RawValues() [][]byte {
+	return nil
+}
+
+func (r *fakePgxRows) Conn() *pgx.Conn {
+	return nil
+}
+
+func TestPgxIterator(t *testing.T) {
+	sampleRows := func() *fakePgxRows {
+		return newFakePgxRows([]string{"a", "b"}, []any{1, "x"}, []any{2, "y"})
+	}
+
+	t.Run("should Collect 2 items", func(t *testing.T) {
+		iterator := NewPgxIterator[testdb.DummyRow](sampleRows())
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []testdb.DummyRow{{A: 1, B: "x"}, {A: 2, B: "y"}}, items)
+	})
+
+	t.Run("should CollectPtr 2 items", func(t *testing.T) {
+		rows := sampleRows()
+		iterator := NewPgxIterator[testdb.DummyRow](rows, WithRowsPreallocatedItems(10))
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, 10, cap(items))
+		require.True(t, rows.closed)
+	})
+
+	t.Run("should Collect with a custom mapper", func(t *testing.T) {
+		iterator := NewPgxIteratorFunc[int](newFakePgxRows([]string{"a"}, []any{1}, []any{2}), pgx.RowTo[int])
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("should report cursor error after the last row", func(t *testing.T) {
+		errTest := errors.New("test error")
+		rows := sampleRows()
+		rows.err = errTest
+		iterator := NewPgxIterator[testdb.DummyRow](rows)
+
+		count := 0
+		for iterator.Next() {
+			_, err := iterator.Item()
+			require.NoError(t, err)
+			count++
+		}
+		require.Equal(t, 2, count)
+		require.ErrorIs(t, iterator.Err(), errTest)
+		require.False(t, iterator.Next())
+		require.ErrorIs(t, iterator.Close(), errTest)
+	})
+
+	t.Run("should error on unmapped column", func(t *testing.T) {
+		iterator := NewPgxIterator[testdb.DummyRow](newFakePgxRows([]string{"a", "z"}, []any{1, "x"}))
+
+		_, err := iterator.Collect()
+		require.Error(t, err)
+	})
+}
+
+type pgxTypesRow struct {
+	A    int            `db:"a"`
+	Tags []int32        `db:"tags"`
+	Doc  map[string]any `db:"doc"`
+	Num  float64        `db:"num"`
+}
+
+func TestPgxIteratorWithDB(t *testing.T) {
+	dbName := testdb.UniqueDBName()
+	_, err := testdb.CreateDBAndData(dbName)
+	require.NoError(t, err)
+
+	conn, err := testdb.OpenPgxConn(dbName)
+	require.NoError(t, err)
+
+	t.Run("should Collect 2 items", func(t *testing.T) {
+		rows, err := testdb.OpenPgxCursor(conn)
+		require.NoError(t, err)
+
+		iterator := NewPgxIterator[testdb.DummyRow](rows)
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []testdb.DummyRow{{A: 1, B: "x"}, {A: 2, B: "y"}}, items)
+	})
+
+	t.Run("should scan pgx types", func(t *testing.T) {
+		rows, err := conn.Query(context.Background(),
+			`SELECT 1 AS a, ARRAY[1,2]::integer[] AS tags, '{"k":"v"}'::jsonb AS doc, 1.5::numeric AS num`,
+		)
+		require.NoError(t, err)
+
+		iterator := NewPgxIterator[pgxTypesRow](rows)
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.True(t, reflect.DeepEqual(pgxTypesRow{
+			A:    1,
+			Tags: []int32{1, 2},
+			Doc:  map[string]any{"k": "v"},
+			Num:  1.5,
+		}, items[0]))
+	})
+
+	t.Run("should report query error", func(t *testing.T) {
+		rows, err := conn.Query(context.Background(), `SELECT 1/0 AS a, 'x' AS b`)
+		require.NoError(t, err)
+
+		iterator := NewPgxIterator[testdb.DummyRow](rows)
+		_, err = iterator.Collect()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,168 @@
+package iterators
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+)
+
+var _ StructIterator[dummy] = &RateLimitedIterator[dummy]{}
+
+type (
+	// RateLimitedIterator paces the items delivered by any iterator, e.g. to stay below the rate limit of a downstream API.
+	//
+	// Calls to Next() are throttled with a token bucket: at most burst items are delivered at once,
+	// then items are delivered at the given rate.
+	//
+	// The iterator stops when the context is cancelled while waiting: the underlying iterator is closed
+	// and the context error is reported by Err().
+	//
+	// Notice that the rate-limited iterator is not goroutine-safe and should not be iterated concurrently.
+	RateLimitedIterator[T any] struct {
+		releasable[T]
+		ctx    context.Context
+		bucket *tokenBucket
+		err    error
+
+		*rowsIteratorOptions
+	}
+
+	// tokenBucket is a minimal token bucket, refilled at a constant rate up to burst tokens.
+	tokenBucket struct {
+		interval time.Duration
+		burst    int
+		tokens   int
+		last     time.Time
+	}
+)
+
+// NewRateLimitedIterator makes a StructIterator[T] delivering at most rate items per second from the underlying iterator,
+// with bursts of at most burst items.
+//
+// The bucket starts full, so the first burst items are delivered without waiting.
+//
+// A rate lower than or equal to 0 disables the rate limit. A burst lower than 1 is interpreted as 1.
+//
+// The rate-limited iterator may be combined with other iterators, e.g. a TransformIterator to call an API for every item:
+//
+//	iterator := NewTransformIterator[T, U](ctx, NewRateLimitedIterator[T](ctx, source, 10, 1), callAPI)
+func NewRateLimitedIterator[T any](ctx context.Context, iterator StructIterator[T], rate float64, burst int, opts ...RowsIteratorOption) *RateLimitedIterator[T] {
+	ri := &RateLimitedIterator[T]{
+		releasable:          releasable[T]{source: iterator},
+		ctx:                 ctx,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+
+	if interval := time.Duration(float64(time.Second) / rate); rate > 0 && interval > 0 {
+		burst = max(burst, 1)
+		ri.bucket = &tokenBucket{
+			interval: interval,
+			burst:    burst,
+			tokens:   burst,
+			last:     time.Now(),
+		}
+	}
+
+	return ri
+}
+
+func (ri *RateLimitedIterator[T]) Next() bool {
+	if ri.released || !ri.source.Next() {
+		// the end of the stream is not paced
+		return false
+	}
+
+	if ri.bucket != nil {
+		if err := ri.bucket.wait(ri.ctx); err != nil {
+			ri.err = err
+			ri.release()
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func (ri *RateLimitedIterator[T]) Item() (T, error) {
+	if ri.released {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return ri.source.Item()
+}
+
+// Err returns the context error if the iterator was interrupted while waiting,
+// or the error reported by the underlying iterator.
+func (ri *RateLimitedIterator[T]) Err() error {
+	if ri.err != nil {
+		return ri.err
+	}
+
+	return ri.releasable.Err()
+}
+
+func (ri *RateLimitedIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](ri, ri.preallocatedItems)
+}
+
+func (ri *RateLimitedIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](ri, ri.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ri *RateLimitedIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](ri)
+}
+
+// wait blocks until a token is available, then consumes it.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.refill(time.Now())
+	if b.tokens > 0 {
+		b.tokens--
+
+		return nil
+	}
+
+	timer := time.NewTimer(b.interval - time.Since(b.last))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	// the token refilled while waiting is consumed right away
+	b.refill(time.Now())
+	b.tokens = max(b.tokens-1, 0)
+
+	return nil
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	accumulated := int(now.Sub(b.last) / b.interval)
+	if accumulated == 0 {
+		return
+	}
+
+	if b.tokens+accumulated >= b.burst {
+		b.tokens = b.burst
+		b.last = now
+
+		return
+	}
+
+	b.tokens += accumulated
+	b.last = b.last.Add(time.Duration(accumulated) * b.interval)
+}
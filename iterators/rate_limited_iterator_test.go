@@ -0,0 +1,87 @@
+package iterators
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedIterator(t *testing.T) {
+	t.Run("should pace items after the burst", func(t *testing.T) {
+		const rate = 50.0 // one item every 20ms
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(6)))
+		iterator := NewRateLimitedIterator[int](context.Background(), tracker, rate, 2, WithRowsPreallocatedItems(10))
+
+		start := time.Now()
+		items, err := iterator.Collect()
+		elapsed := time.Since(start)
+
+		require.NoError(t, err)
+		require.Equal(t, intSlice(6), items)
+		require.Equal(t, 10, cap(items))
+		require.True(t, tracker.isClosed())
+		// 2 items in the initial burst, then 4 items paced at 20ms
+		require.GreaterOrEqual(t, elapsed, 4*20*time.Millisecond)
+	})
+
+	t.Run("should not wait for the end of the stream", func(t *testing.T) {
+		iterator := NewRateLimitedIterator[int](context.Background(), NewSliceIterator([]int{1}), 1, 1) // one item per second
+
+		start := time.Now()
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, items)
+		require.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("should not wait without a rate", func(t *testing.T) {
+		iterator := NewRateLimitedIterator[int](context.Background(), NewSliceIterator(intSlice(6)), 0, 0)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, len(intSlice(6)))
+	})
+
+	t.Run("should stop when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(6)))
+		iterator := NewRateLimitedIterator[int](ctx, tracker, 1, 1) // one item per second
+
+		require.True(t, iterator.Next())
+		item, err := iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, 1, item)
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+		start := time.Now()
+		require.False(t, iterator.Next())
+		require.Less(t, time.Since(start), time.Second)
+		require.ErrorIs(t, iterator.Err(), context.Canceled)
+		require.True(t, tracker.isClosed())
+
+		_, err = iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should combine with a transform", func(t *testing.T) {
+		ctx := context.Background()
+		limited := NewRateLimitedIterator[int](ctx, NewSliceIterator(intSlice(6)), 1000, 1)
+		iterator := NewTransformIterator[int, string](ctx, limited, func(_ context.Context, in int) (string, error) {
+			return strconv.Itoa(in), nil
+		})
+
+		var items []string
+		for item, err := range iterator.All() {
+			require.NoError(t, err)
+			items = append(items, item)
+		}
+		require.Equal(t, []string{"1", "2", "3", "4", "5", "6"}, items)
+	})
+}
@@ -1,6 +1,7 @@
 package iterators
 
 import (
+	"iter"
 	"sync"
 
 	"github.com/jmoiron/sqlx"
@@ -14,11 +15,17 @@ type (
 	//
 	// Rows iteratated over R are scanned into structs of type T.
 	//
+	// If R exposes an Err() error method (like sqlx.Rows), the cursor error is checked when the iteration stops
+	// and reported by Err().
+	//
+	// With the WithContext option, the iteration stops when the context is cancelled, and the cursor is closed.
+	//
 	// Notice that the rows iterator is not goroutine-safe and should not be iterated concurrently.
 	RowsIterator[R ScannableIterator, T any] struct {
 		rows     R
 		mx       sync.Mutex
 		isClosed bool
+		err      error
 
 		*rowsIteratorOptions
 	}
@@ -57,7 +64,31 @@ func (ri *RowsIterator[R, T]) Close() error {
 }
 
 func (ri *RowsIterator[R, T]) Next() bool {
-	return ri.rows.Next()
+	if ri.err != nil {
+		return false
+	}
+
+	if err := ri.ctxErr(); err != nil {
+		ri.err = err
+		_ = ri.Close()
+
+		return false
+	}
+
+	if ri.rows.Next() {
+		return true
+	}
+
+	if cursor, ok := any(ri.rows).(ErrIterator); ok {
+		ri.err = cursor.Err()
+	}
+
+	return false
+}
+
+// Err returns the cursor error or the context error that interrupted the iteration, if any.
+func (ri *RowsIterator[R, T]) Err() error {
+	return ri.err
 }
 
 func (ri *RowsIterator[R, T]) Item() (T, error) {
@@ -78,6 +109,22 @@ func (ri *RowsIterator[R, T]) CollectPtr() ([]*T, error) {
 	return collectPtrAndClose[T](ri, ri.preallocatedItems)
 }
 
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ri *RowsIterator[R, T]) All() iter.Seq2[T, error] {
+	return Seq[T](ri)
+}
+
+// iteratorErr returns the error that interrupted an iterator, if it reports any.
+func iteratorErr(iterator Iterator) error {
+	if reporter, ok := iterator.(ErrIterator); ok {
+		return reporter.Err()
+	}
+
+	return nil
+}
+
 func collectAndClose[T any](ri baseIterator[T], preallocatedItems int) ([]T, error) {
 	collection := make([]T, 0, preallocatedItems)
 
@@ -92,6 +139,12 @@ func collectAndClose[T any](ri baseIterator[T], preallocatedItems int) ([]T, err
 		collection = append(collection, item)
 	}
 
+	if err := ri.Err(); err != nil {
+		_ = ri.Close()
+
+		return collection, err
+	}
+
 	if err := ri.Close(); err != nil {
 		return collection, err
 	}
@@ -113,6 +166,12 @@ func collectPtrAndClose[T any](ri baseIterator[T], preallocatedItems int) ([]*T,
 		collection = append(collection, &item)
 	}
 
+	if err := ri.Err(); err != nil {
+		_ = ri.Close()
+
+		return collection, err
+	}
+
 	if err := ri.Close(); err != nil {
 		return collection, err
 	}
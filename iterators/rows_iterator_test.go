@@ -1,6 +1,8 @@
 package iterators
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/fredbi/go-patterns/iterators/internal/testdb"
@@ -132,3 +134,76 @@ func TestRowsIterator(t *testing.T) {
 		})
 	})
 }
+
+// fakeScannableRows mimics a sqlx.Rows cursor over in-memory values.
+type fakeScannableRows struct {
+	*fakeRows
+}
+
+func (r fakeScannableRows) StructScan(dest interface{}) error {
+	return r.Scan(dest)
+}
+
+func TestRowsIteratorErr(t *testing.T) {
+	errTest := errors.New("test error")
+	sampleRows := func() fakeScannableRows {
+		rows := newFakeRows([]string{"id"}, []any{1}, []any{2})
+		rows.err = errTest
+
+		return fakeScannableRows{fakeRows: rows}
+	}
+
+	t.Run("should report the cursor error when the iteration stops", func(t *testing.T) {
+		iterator := NewRowsIterator[fakeScannableRows, int](sampleRows())
+
+		count := 0
+		for iterator.Next() {
+			_, err := iterator.Item()
+			require.NoError(t, err)
+			count++
+		}
+		require.Equal(t, 2, count)
+		require.ErrorIs(t, iterator.Err(), errTest)
+		require.False(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should Collect items then error", func(t *testing.T) {
+		rows := sampleRows()
+		iterator := NewRowsIterator[fakeScannableRows, int](rows)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, []int{1, 2}, items)
+		require.True(t, rows.closed)
+	})
+}
+
+func TestRowsIteratorWithContext(t *testing.T) {
+	t.Run("should stop and close the cursor when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rows := fakeScannableRows{fakeRows: newFakeRows([]string{"id"}, []any{1}, []any{2})}
+		iterator := NewRowsIterator[fakeScannableRows, int](rows, WithContext(ctx))
+
+		require.True(t, iterator.Next())
+		cancel()
+		require.False(t, iterator.Next())
+		require.True(t, rows.closed)
+		require.ErrorIs(t, iterator.Err(), context.Canceled)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should report the context error from Collect", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		rows := fakeScannableRows{fakeRows: newFakeRows([]string{"id"}, []any{1})}
+		iterator := NewRowsIterator[fakeScannableRows, int](rows, WithContext(ctx))
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, items)
+	})
+}
@@ -0,0 +1,163 @@
+package iterators
+
+import (
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+var _ StructIterator[dummy] = &ScanIterator[*sql.Rows, dummy]{}
+
+type (
+	// ColumnScanner is an iterator over DB records which columns can be scanned, such as sql.Rows.
+	ColumnScanner interface {
+		Iterator
+		Columns() ([]string, error)
+		Scan(...any) error
+		Err() error
+	}
+
+	// ScanIterator transforms a ColumnScanner of type R (e.g. a DB cursor such as sql.Rows)
+	// into a StructIterator with target type T.
+	//
+	// Unlike the RowsIterator, it does not require sqlx: columns are mapped to the fields of T using "db" struct tags.
+	// Like with sqlx, fields without tags are mapped to their lower-cased name.
+	//
+	// Fields of embedded structs are mapped as well. Fields implementing sql.Scanner are scanned directly.
+	//
+	// If T is not a struct, or implements sql.Scanner, rows are expected to have a single column, which
+	// is scanned directly into T.
+	//
+	// Notice that the scan iterator is not goroutine-safe and should not be iterated concurrently.
+	ScanIterator[R ColumnScanner, T any] struct {
+		rows     R
+		mx       sync.Mutex
+		isClosed bool
+		indexes  [][]int
+		err      error
+
+		*rowsIteratorOptions
+	}
+
+	// SQLIterator is a shorthand for ScanIterator[*sql.Rows, T].
+	SQLIterator[T any] struct {
+		*ScanIterator[*sql.Rows, T]
+	}
+)
+
+// NewSQLIterator makes a SQLIterator[T] producing items of type T from a database/sql.Rows cursor.
+func NewSQLIterator[T any](rows *sql.Rows, opts ...RowsIteratorOption) *SQLIterator[T] {
+	return &SQLIterator[T]{
+		ScanIterator: NewScanIterator[*sql.Rows, T](rows, opts...),
+	}
+}
+
+// NewScanIterator makes a StructIterator[T] from a ColumnScanner.
+func NewScanIterator[R ColumnScanner, T any](rows R, opts ...RowsIteratorOption) *ScanIterator[R, T] {
+	return &ScanIterator[R, T]{
+		rows:                rows,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (si *ScanIterator[R, T]) Close() error {
+	si.mx.Lock()
+	defer si.mx.Unlock()
+
+	if si.isClosed {
+		return nil
+	}
+	si.isClosed = true
+
+	return si.rows.Close()
+}
+
+func (si *ScanIterator[R, T]) Next() bool {
+	if si.err != nil {
+		return false
+	}
+
+	if err := si.ctxErr(); err != nil {
+		si.err = err
+		_ = si.Close()
+
+		return false
+	}
+
+	if si.rows.Next() {
+		return true
+	}
+
+	si.err = si.rows.Err()
+
+	return false
+}
+
+// Err returns the cursor error or the context error that interrupted the iteration, if any.
+func (si *ScanIterator[R, T]) Err() error {
+	return si.err
+}
+
+func (si *ScanIterator[R, T]) Item() (T, error) {
+	var data T
+
+	target := reflect.ValueOf(&data).Elem()
+	if isScannable(target.Type()) {
+		if err := si.rows.Scan(&data); err != nil {
+			return data, err
+		}
+
+		return data, nil
+	}
+
+	if si.indexes == nil {
+		if err := si.prepare(target.Type()); err != nil {
+			return data, err
+		}
+	}
+
+	destinations := make([]any, len(si.indexes))
+	for i, path := range si.indexes {
+		destinations[i] = fieldByIndex(target, path).Addr().Interface()
+	}
+
+	if err := si.rows.Scan(destinations...); err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+// prepare resolves the fields of T targeted by the columns of the cursor.
+func (si *ScanIterator[R, T]) prepare(t reflect.Type) error {
+	columns, err := si.rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	indexes, err := fieldPlanFor(t, "db").indexes(t, columns)
+	if err != nil {
+		return fmt.Errorf("cannot scan columns: %w", err)
+	}
+
+	si.indexes = indexes
+
+	return nil
+}
+
+func (si *ScanIterator[R, T]) Collect() ([]T, error) {
+	return collectAndClose[T](si, si.preallocatedItems)
+}
+
+func (si *ScanIterator[R, T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](si, si.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (si *ScanIterator[R, T]) All() iter.Seq2[T, error] {
+	return Seq[T](si)
+}
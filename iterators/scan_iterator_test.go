@@ -0,0 +1,289 @@
+package iterators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fredbi/go-patterns/iterators/internal/testdb"
+	"github.com/stretchr/testify/require"
+)
+
+var _ ColumnScanner = &fakeRows{}
+
+// fakeRows mimics a database/sql.Rows cursor over in-memory values.
+type fakeRows struct {
+	columns []string
+	values  [][]any
+	index   int
+	err     error
+	closed  bool
+}
+
+func newFakeRows(columns []string, values ...[]any) *fakeRows {
+	return &fakeRows{columns: columns, values: values, index: -1}
+}
+
+func (r *fakeRows) Next() bool {
+	if r.closed {
+		return false
+	}
+	r.index++
+
+	return r.index < len(r.values)
+}
+
+func (r *fakeRows) Close() error {
+	r.closed = true
+
+	return nil
+}
+
+func (r *fakeRows) Columns() ([]string, error) {
+	return r.columns, nil
+}
+
+func (r *fakeRows) Err() error {
+	if r.index >= len(r.values) {
+		return r.err
+	}
+
+	return nil
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if r.index < 0 || r.index >= len(r.values) {
+		return errors.New("no current row")
+	}
+
+	row := r.values[r.index]
+	if len(dest) != len(row) {
+		return fmt.Errorf("expected %d destination arguments in Scan, not %d", len(row), len(dest))
+	}
+
+	for i, value := range row {
+		if scanner, ok := dest[i].(sql.Scanner); ok {
+			if err := scanner.Scan(value); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		target := reflect.ValueOf(dest[i]).Elem()
+		if value == nil {
+			target.Set(reflect.Zero(target.Type()))
+
+			continue
+		}
+
+		source := reflect.ValueOf(value)
+		if target.Kind() == reflect.Pointer {
+			ptr := reflect.New(target.Type().Elem())
+			ptr.Elem().Set(source.Convert(target.Type().Elem()))
+			target.Set(ptr)
+
+			continue
+		}
+
+		if !source.Type().ConvertibleTo(target.Type()) {
+			return fmt.Errorf("cannot convert column %d from %T to %v", i, value, target.Type())
+		}
+		target.Set(source.Convert(target.Type()))
+	}
+
+	return nil
+}
+
+type (
+	upperString string
+
+	scanBase struct {
+		ID int `db:"id"`
+	}
+
+	ScanAudit struct {
+		Author string `db:"author"`
+	}
+
+	scanTarget struct {
+		scanBase
+		*ScanAudit
+		Name     upperString    `db:"name"`
+		Comment  *string        `db:"comment"`
+		Optional sql.NullString `db:"optional"`
+		Untagged int
+		Ignored  int `db:"-"`
+	}
+)
+
+func (u *upperString) Scan(value any) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", value)
+	}
+	*u = upperString(strings.ToUpper(s))
+
+	return nil
+}
+
+func TestScanIterator(t *testing.T) {
+	columns := []string{"id", "author", "name", "comment", "optional", "untagged"}
+	sampleRows := func() *fakeRows {
+		return newFakeRows(columns,
+			[]any{1, "fred", "a", "first", "x", 10},
+			[]any{2, "mat", "b", nil, nil, 20},
+		)
+	}
+
+	t.Run("should Collect mapped structs", func(t *testing.T) {
+		iterator := NewScanIterator[*fakeRows, scanTarget](sampleRows())
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+
+		first := items[0]
+		require.Equal(t, 1, first.ID)
+		require.NotNil(t, first.ScanAudit)
+		require.Equal(t, "fred", first.Author)
+		require.Equal(t, upperString("A"), first.Name)
+		require.NotNil(t, first.Comment)
+		require.Equal(t, "first", *first.Comment)
+		require.Equal(t, sql.NullString{String: "x", Valid: true}, first.Optional)
+		require.Equal(t, 10, first.Untagged)
+
+		second := items[1]
+		require.Equal(t, 2, second.ID)
+		require.Nil(t, second.Comment)
+		require.False(t, second.Optional.Valid)
+	})
+
+	t.Run("should CollectPtr mapped structs", func(t *testing.T) {
+		rows := sampleRows()
+		iterator := NewScanIterator[*fakeRows, scanTarget](rows, WithRowsPreallocatedItems(10))
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, 10, cap(items))
+		require.True(t, rows.closed)
+	})
+
+	t.Run("should scan a single column into a scalar", func(t *testing.T) {
+		iterator := NewScanIterator[*fakeRows, int](newFakeRows([]string{"id"}, []any{1}, []any{2}))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("should error on unknown column", func(t *testing.T) {
+		iterator := NewScanIterator[*fakeRows, scanTarget](newFakeRows([]string{"id", "unknown"}, []any{1, 2}))
+
+		_, err := iterator.Collect()
+		require.ErrorContains(t, err, "missing destination name unknown")
+	})
+
+	t.Run("should error on cursor error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		rows := sampleRows()
+		rows.err = errTest
+		iterator := NewScanIterator[*fakeRows, scanTarget](rows)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, items, 2)
+		require.False(t, iterator.Next())
+		require.ErrorIs(t, iterator.Err(), errTest)
+	})
+
+	t.Run("should stop when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		rows := sampleRows()
+		iterator := NewScanIterator[*fakeRows, scanTarget](rows, WithContext(ctx))
+
+		require.True(t, iterator.Next())
+		cancel()
+		require.False(t, iterator.Next())
+		require.True(t, rows.closed)
+		require.ErrorIs(t, iterator.Err(), context.Canceled)
+	})
+
+	t.Run("with empty cursor", func(t *testing.T) {
+		iterator := NewScanIterator[*fakeRows, scanTarget](newFakeRows(columns))
+
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.Error(t, err)
+		require.NoError(t, iterator.Close())
+
+		t.Run("should not error if closed twice", func(t *testing.T) {
+			require.NoError(t, iterator.Close())
+		})
+	})
+
+	t.Run("should cache field plans", func(t *testing.T) {
+		typ := reflect.TypeOf(scanTarget{})
+		plan := fieldPlanFor(typ, "db")
+		require.Same(t, plan, fieldPlanFor(typ, "db"))
+		require.NotContains(t, plan.fields, "ignored")
+		require.Equal(t, []int{0, 0}, plan.fields["id"])
+		require.Equal(t, []int{1, 0}, plan.fields["author"])
+	})
+
+	t.Run("should map the shallowest field", func(t *testing.T) {
+		type (
+			deep struct {
+				X int `db:"x"`
+			}
+			middle struct {
+				deep
+			}
+			shadowing struct {
+				X int `db:"x"`
+			}
+			target struct {
+				middle
+				shadowing
+			}
+		)
+
+		plan := fieldPlanFor(reflect.TypeOf(target{}), "db")
+		require.Equal(t, []int{1, 0}, plan.fields["x"])
+		require.Equal(t, []string{"x"}, plan.columns)
+	})
+}
+
+func TestSQLIterator(t *testing.T) {
+	dbName := testdb.UniqueDBName()
+	db, err := testdb.CreateDBAndData(dbName)
+	require.NoError(t, err)
+
+	t.Run("should Collect 2 items", func(t *testing.T) {
+		rows, err := testdb.OpenDBCursor(db)
+		require.NoError(t, err)
+
+		iterator := NewSQLIterator[testdb.DummyRow](rows.Rows)
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []testdb.DummyRow{{A: 1, B: "x"}, {A: 2, B: "y"}}, items)
+	})
+
+	t.Run("should error on closed cursor", func(t *testing.T) {
+		rows, err := testdb.OpenDBCursor(db)
+		require.NoError(t, err)
+
+		iterator := NewSQLIterator[testdb.DummyRow](rows.Rows)
+		require.True(t, iterator.Next())
+		require.NoError(t, rows.Close())
+		_, err = iterator.Item()
+		require.Error(t, err)
+		require.NotErrorIs(t, err, io.EOF)
+	})
+}
@@ -0,0 +1,167 @@
+package iterators
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+	fieldPlans sync.Map // map[fieldPlanKey]*fieldPlan
+)
+
+type (
+	// fieldPlan maps column names to the index path of struct fields.
+	fieldPlan struct {
+		fields  map[string][]int
+		columns []string // column names, in the order of the fields
+	}
+
+	fieldPlanKey struct {
+		typ  reflect.Type
+		tags string
+	}
+)
+
+// fieldPlanFor returns the cached field plan for struct type t, using the given struct tags to name columns.
+//
+// When several tags are provided, the first tag found on a field is used.
+//
+// Like with sqlx, a field without tag is named after the lower-cased field name. Fields tagged with "-" are ignored.
+//
+// Embedded structs are walked recursively, unless they implement sql.Scanner.
+func fieldPlanFor(t reflect.Type, tags ...string) *fieldPlan {
+	key := fieldPlanKey{typ: t, tags: strings.Join(tags, ",")}
+	if plan, ok := fieldPlans.Load(key); ok {
+		return plan.(*fieldPlan)
+	}
+
+	plan := &fieldPlan{fields: make(map[string][]int)}
+	buildFieldPlan(plan, t, tags, nil)
+	actual, _ := fieldPlans.LoadOrStore(key, plan)
+
+	return actual.(*fieldPlan)
+}
+
+func buildFieldPlan(plan *fieldPlan, t reflect.Type, tags []string, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, hasTag := lookupTags(field.Tag, tags)
+		name, _, _ = strings.Cut(name, ",")
+		if name == "-" {
+			continue
+		}
+
+		path := make([]int, len(index)+1)
+		copy(path, index)
+		path[len(index)] = i
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct && !isScanner(fieldType) {
+			if field.Type.Kind() == reflect.Pointer && !field.IsExported() {
+				// an unexported embedded pointer can't be allocated
+				continue
+			}
+
+			buildFieldPlan(plan, fieldType, tags, path)
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if !hasTag || name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		if existing, exists := plan.fields[name]; exists {
+			if len(path) >= len(existing) {
+				// like with go field promotion, the shallowest field wins
+				continue
+			}
+		} else {
+			plan.columns = append(plan.columns, name)
+		}
+
+		plan.fields[name] = path
+	}
+}
+
+// lookupTags returns the value of the first struct tag found among tags.
+func lookupTags(structTag reflect.StructTag, tags []string) (string, bool) {
+	for _, tag := range tags {
+		if name, ok := structTag.Lookup(tag); ok {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// isScanner tells if a pointer to type t implements sql.Scanner.
+func isScanner(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(scannerType)
+}
+
+// isScannable tells if type t is scanned directly as a single column, rather than mapped as a struct.
+func isScannable(t reflect.Type) bool {
+	return t.Kind() != reflect.Struct || isScanner(t)
+}
+
+// indexes resolves the index paths of fields for a list of columns.
+func (p *fieldPlan) indexes(t reflect.Type, columns []string) ([][]int, error) {
+	indexes := make([][]int, 0, len(columns))
+
+	for _, column := range columns {
+		path, ok := p.fields[column]
+		if !ok {
+			return nil, fmt.Errorf("missing destination name %s in %v", column, t)
+		}
+
+		indexes = append(indexes, path)
+	}
+
+	return indexes, nil
+}
+
+// fieldByIndexNoAlloc returns the field of v at the index path, or false if an embedded pointer on the path is nil.
+func fieldByIndexNoAlloc(v reflect.Value, path []int) (reflect.Value, bool) {
+	for i, idx := range path {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+
+		v = v.Field(idx)
+	}
+
+	return v, true
+}
+
+// fieldByIndex returns the addressable field of v at the index path, allocating embedded pointers as needed.
+func fieldByIndex(v reflect.Value, path []int) reflect.Value {
+	for i, idx := range path {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		v = v.Field(idx)
+	}
+
+	return v
+}
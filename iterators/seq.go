@@ -0,0 +1,54 @@
+package iterators
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// Seq adapts any StructIterator[T] into a range-over-func sequence.
+//
+// Items are yielded together with a nil error. An error returned by Item() is yielded with an empty item,
+// and the sequence continues: the consumer may break out of the loop to stop on the first error.
+// An io.EOF returned by Item() is interpreted as the end of the stream (e.g. with a ChanIterator).
+//
+// When the iterator implements ErrIterator, an error reported by Err() is yielded once with an empty item,
+// and the sequence stops.
+//
+// The underlying iterator is always closed when the sequence is done, including when the
+// consumer breaks out of the loop early. An error returned by Close() after a complete iteration
+// is yielded as a last element.
+//
+// Like Collect(), the sequence consumes the iterator and may only be ranged over once.
+func Seq[T any](iterator StructIterator[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var empty T
+
+		for iterator.Next() {
+			item, err := iterator.Item()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				item = empty
+			}
+
+			if !yield(item, err) {
+				_ = iterator.Close()
+
+				return
+			}
+		}
+
+		if err := iteratorErr(iterator); err != nil {
+			_ = yield(empty, preferErrorOverContext(err, iterator.Close()))
+
+			return
+		}
+
+		if err := iterator.Close(); err != nil {
+			_ = yield(empty, err)
+		}
+	}
+}
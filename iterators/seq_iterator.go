@@ -0,0 +1,122 @@
+package iterators
+
+import (
+	"io"
+	"iter"
+	"sync"
+)
+
+var _ StructIterator[dummy] = &SeqIterator[dummy]{}
+
+// SeqIterator adapts a range-over-func sequence (e.g. a generator function) into a StructIterator.
+//
+// The sequence is consumed in pull mode: Close() must be called to stop the generator
+// whenever the iteration is not completed.
+type SeqIterator[T any] struct {
+	next     func() (T, error, bool)
+	stop     func()
+	current  T
+	err      error
+	started  bool
+	done     bool
+	isClosed bool
+	mx       sync.Mutex
+
+	*rowsIteratorOptions
+}
+
+// FromSeq makes a StructIterator[T] from an iter.Seq[T].
+func FromSeq[T any](seq iter.Seq[T], opts ...RowsIteratorOption) *SeqIterator[T] {
+	return FromSeq2[T](func(yield func(T, error) bool) {
+		for item := range seq {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}, opts...)
+}
+
+// FromSeq2 makes a StructIterator[T] from an iter.Seq2[T, error].
+//
+// Errors produced by the sequence are returned by Item().
+func FromSeq2[T any](seq iter.Seq2[T, error], opts ...RowsIteratorOption) *SeqIterator[T] {
+	next, stop := iter.Pull2(seq)
+
+	return &SeqIterator[T]{
+		next:                next,
+		stop:                stop,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (si *SeqIterator[T]) Next() bool {
+	si.mx.Lock()
+	defer si.mx.Unlock()
+
+	if si.isClosed || si.done {
+		return false
+	}
+
+	si.started = true
+	item, err, ok := si.next()
+	if !ok {
+		var empty T
+		si.current = empty
+		si.err = nil
+		si.done = true
+
+		return false
+	}
+
+	si.current = item
+	si.err = err
+
+	return true
+}
+
+func (si *SeqIterator[T]) Item() (T, error) {
+	si.mx.Lock()
+	defer si.mx.Unlock()
+
+	if !si.started || si.done || si.isClosed {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return si.current, si.err
+}
+
+// Err always returns nil: errors yielded by a sequence are reported by Item().
+func (si *SeqIterator[T]) Err() error {
+	return nil
+}
+
+// Close stops the underlying generator.
+func (si *SeqIterator[T]) Close() error {
+	si.mx.Lock()
+	defer si.mx.Unlock()
+
+	if si.isClosed {
+		return nil
+	}
+	si.isClosed = true
+	si.stop()
+
+	return nil
+}
+
+func (si *SeqIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](si, si.preallocatedItems)
+}
+
+func (si *SeqIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](si, si.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (si *SeqIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](si)
+}
@@ -0,0 +1,126 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeqIterator(t *testing.T) {
+	t.Run("with FromSeq", func(t *testing.T) {
+		t.Run("should Collect 2 items", func(t *testing.T) {
+			iterator := FromSeq(slices.Values(dummySlice()))
+
+			items, err := iterator.Collect()
+			require.NoError(t, err)
+			require.Equal(t, dummySlice(), items)
+		})
+
+		t.Run("should CollectPtr 2 items", func(t *testing.T) {
+			iterator := FromSeq(slices.Values(dummySlice()), WithRowsPreallocatedItems(10))
+
+			items, err := iterator.CollectPtr()
+			require.NoError(t, err)
+			require.Len(t, items, 2)
+			require.Equal(t, 10, cap(items))
+		})
+
+		t.Run("should feed a TransformIterator", func(t *testing.T) {
+			transformer := func(_ context.Context, in dummyStruct) (int, error) {
+				return in.A, nil
+			}
+			iterator := NewTransformIterator[dummyStruct, int](context.Background(), FromSeq(slices.Values(dummySlice())), transformer)
+
+			items, err := iterator.Collect()
+			require.NoError(t, err)
+			require.Equal(t, []int{1, 2}, items)
+		})
+
+		t.Run("should feed a ChanIterator", func(t *testing.T) {
+			baseIterators := []StructIterator[dummyStruct]{
+				FromSeq(slices.Values(dummySlice())),
+				FromSeq(slices.Values(dummySlice())),
+			}
+			iterator := NewChanIterator[dummyStruct](context.Background(), baseIterators)
+
+			items, err := iterator.Collect()
+			require.NoError(t, err)
+			require.Len(t, items, 4)
+		})
+	})
+
+	t.Run("with FromSeq2", func(t *testing.T) {
+		errTest := errors.New("test error")
+		generator := func(yield func(dummyStruct, error) bool) {
+			if !yield(dummyStruct{A: 1}, nil) {
+				return
+			}
+
+			_ = yield(dummyStruct{}, errTest)
+		}
+
+		t.Run("should return error from Item()", func(t *testing.T) {
+			iterator := FromSeq2(generator)
+
+			require.True(t, iterator.Next())
+			item, err := iterator.Item()
+			require.NoError(t, err)
+			require.Equal(t, 1, item.A)
+
+			require.True(t, iterator.Next())
+			_, err = iterator.Item()
+			require.ErrorIs(t, err, errTest)
+
+			require.False(t, iterator.Next())
+			_, err = iterator.Item()
+			require.ErrorIs(t, err, io.EOF)
+
+			require.NoError(t, iterator.Close())
+		})
+
+		t.Run("should Collect 1 item then error", func(t *testing.T) {
+			iterator := FromSeq2(generator)
+
+			items, err := iterator.Collect()
+			require.ErrorIs(t, err, errTest)
+			require.Len(t, items, 1)
+		})
+	})
+
+	t.Run("should stop the generator on Close()", func(t *testing.T) {
+		var stopped bool
+		generator := func(yield func(int) bool) {
+			defer func() {
+				stopped = true
+			}()
+
+			for i := 0; ; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		iterator := FromSeq(generator)
+
+		require.True(t, iterator.Next())
+		require.True(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+		require.True(t, stopped)
+		require.False(t, iterator.Next())
+
+		t.Run("should not error if closed twice", func(t *testing.T) {
+			require.NoError(t, iterator.Close())
+		})
+	})
+
+	t.Run("should error if Next() has never been called", func(t *testing.T) {
+		iterator := FromSeq(slices.Values(dummySlice()))
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.NoError(t, iterator.Close())
+	})
+}
@@ -0,0 +1,217 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// closeTracker wraps a StructIterator and records calls to Close().
+type closeTracker[T any] struct {
+	StructIterator[T]
+	closed atomic.Int32
+}
+
+func newCloseTracker[T any](iterator StructIterator[T]) *closeTracker[T] {
+	return &closeTracker[T]{StructIterator: iterator}
+}
+
+func (c *closeTracker[T]) Close() error {
+	c.closed.Add(1)
+
+	return c.StructIterator.Close()
+}
+
+func (c *closeTracker[T]) isClosed() bool {
+	return c.closed.Load() > 0
+}
+
+// eofTerminated wraps a StructIterator and ends the stream like a ChanIterator:
+// Next() returns true one last time, then Item() returns io.EOF.
+type eofTerminated[T any] struct {
+	StructIterator[T]
+	atEOF bool
+}
+
+func newEOFTerminated[T any](iterator StructIterator[T]) *eofTerminated[T] {
+	return &eofTerminated[T]{StructIterator: iterator}
+}
+
+func (e *eofTerminated[T]) Next() bool {
+	if e.StructIterator.Next() {
+		return true
+	}
+
+	if e.atEOF {
+		return false
+	}
+	e.atEOF = true
+
+	return true
+}
+
+func (e *eofTerminated[T]) Item() (T, error) {
+	if e.atEOF {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return e.StructIterator.Item()
+}
+
+func TestSeq(t *testing.T) {
+	t.Run("should range over all items", func(t *testing.T) {
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(dummySlice()))
+		items := make([]dummyStruct, 0, 2)
+
+		for item, err := range Seq[dummyStruct](tracker) {
+			require.NoError(t, err)
+			items = append(items, item)
+		}
+
+		require.Equal(t, dummySlice(), items)
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should close on early break", func(t *testing.T) {
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(dummySlice()))
+		count := 0
+
+		for _, err := range Seq[dummyStruct](tracker) {
+			require.NoError(t, err)
+			count++
+
+			break
+		}
+
+		require.Equal(t, 1, count)
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should yield item errors and continue", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in == 2 {
+				return in, errTest
+			}
+
+			return in, nil
+		}
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(3)))
+		iterator := NewTransformIterator[int, int](context.Background(), tracker, errorer)
+		var (
+			items []int
+			errs  []error
+		)
+
+		for item, err := range iterator.All() {
+			if err != nil {
+				require.Zero(t, item)
+				errs = append(errs, err)
+
+				continue
+			}
+			items = append(items, item)
+		}
+
+		require.Equal(t, []int{1, 3}, items)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], errTest)
+		require.True(t, tracker.isClosed())
+
+		t.Run("should close when breaking on the first error", func(t *testing.T) {
+			tracker := newCloseTracker[int](NewSliceIterator(intSlice(3)))
+			iterator := NewTransformIterator[int, int](context.Background(), tracker, errorer)
+			var items []int
+
+			for item, err := range iterator.All() {
+				if err != nil {
+					break
+				}
+				items = append(items, item)
+			}
+
+			require.Equal(t, []int{1}, items)
+			require.True(t, tracker.isClosed())
+		})
+	})
+
+	t.Run("should yield error reported by Err()", func(t *testing.T) {
+		errTest := errors.New("test error")
+		rows := newFakeRows([]string{"id"}, []any{1}, []any{2})
+		rows.err = errTest
+		iterator := NewScanIterator[*fakeRows, int](rows)
+		var (
+			items []int
+			errs  []error
+		)
+
+		for item, err := range iterator.All() {
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+			items = append(items, item)
+		}
+
+		require.Equal(t, []int{1, 2}, items)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], errTest)
+		require.True(t, rows.closed)
+	})
+
+	t.Run("with ChanIterator", func(t *testing.T) {
+		t.Run("should range over all items", func(t *testing.T) {
+			baseIterators := []StructIterator[dummyStruct]{
+				NewSliceIterator[dummyStruct](dummySlice()),
+				NewSliceIterator[dummyStruct](dummySlice()),
+			}
+			iterator := NewChanIterator[dummyStruct](context.Background(), baseIterators)
+			count := 0
+
+			for _, err := range iterator.All() {
+				require.NoError(t, err)
+				count++
+			}
+
+			require.Equal(t, 4, count)
+		})
+
+		t.Run("should terminate workers on early break", func(t *testing.T) {
+			large := make([]dummyStruct, 1000)
+			trackers := []*closeTracker[dummyStruct]{
+				newCloseTracker[dummyStruct](NewSliceIterator(large)),
+				newCloseTracker[dummyStruct](NewSliceIterator(large)),
+			}
+			baseIterators := []StructIterator[dummyStruct]{trackers[0], trackers[1]}
+			iterator := NewChanIterator[dummyStruct](context.Background(), baseIterators, WithChanFanInBuffers(0))
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+
+				for range iterator.All() {
+					break
+				}
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected workers to be terminated after break")
+			}
+
+			for _, tracker := range trackers {
+				require.True(t, tracker.isClosed())
+			}
+			require.NoError(t, iterator.Close())
+		})
+	})
+}
@@ -2,6 +2,7 @@ package iterators
 
 import (
 	"io"
+	"iter"
 	"sync"
 )
 
@@ -49,6 +50,11 @@ func (si *SliceIterator[T]) Item() (T, error) {
 	return si.rows[si.index], nil
 }
 
+// Err always returns nil, since a SliceIterator never fails.
+func (si *SliceIterator[T]) Err() error {
+	return nil
+}
+
 func (si *SliceIterator[T]) Collect() ([]T, error) {
 	return si.rows, nil
 }
@@ -63,3 +69,10 @@ func (si *SliceIterator[T]) CollectPtr() ([]*T, error) {
 
 	return ptrs, nil
 }
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (si *SliceIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](si)
+}
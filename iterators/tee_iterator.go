@@ -0,0 +1,240 @@
+package iterators
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"sync"
+)
+
+var _ StructIterator[dummy] = &TeeIterator[dummy]{}
+
+// ErrTeeLagging is reported by the Err() method of a TeeIterator detached because it was lagging behind.
+//
+// See WithTeeFailLagging.
+var ErrTeeLagging = errors.New("tee branch lagging behind")
+
+type (
+	// TeeIterator is one of the branches produced by Tee.
+	//
+	// Every branch sees every item of the source iterator, including the items in error.
+	//
+	// Branches are safe to iterate from different goroutines, but every single branch
+	// should not be iterated concurrently.
+	TeeIterator[T any] struct {
+		tee        *tee[T]
+		pos        int // position of the next item, shared with the other branches
+		closed     bool
+		lagging    bool
+		current    teeItem[T]
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+
+	// tee holds the state shared by all branches.
+	tee[T any] struct {
+		mx          sync.Mutex
+		cond        *sync.Cond
+		source      StructIterator[T]
+		bufferSize  int
+		failLagging bool
+		buffer      []teeItem[T]
+		base        int // position of the first buffered item
+		exhausted   bool
+		err         error
+		branches    []*TeeIterator[T]
+		open        int
+	}
+
+	teeItem[T any] struct {
+		item T
+		err  error
+	}
+)
+
+// Tee splits an iterator into n branches, so the source is iterated only once but consumed n times,
+// e.g. to write a cursor to a cache and to a response.
+//
+// At most bufferSize items are retained for the branches lagging behind the fastest one.
+// When the buffer is full, the fastest branches block until the lagging branches catch up.
+// Use WithTeeFailLagging to detach the lagging branches instead.
+//
+// Notice that with the default blocking behavior, branches consumed one after the other
+// by a single goroutine block as soon as the source has more than bufferSize items.
+//
+// The source is closed when all branches are closed.
+//
+// A value of n or bufferSize lower than 1 is interpreted as 1.
+func Tee[T any](iterator StructIterator[T], n int, bufferSize int, opts ...TeeOption) []*TeeIterator[T] {
+	options := teeOptionsWithDefault(opts)
+	n = max(n, 1)
+
+	t := &tee[T]{
+		source:      iterator,
+		bufferSize:  max(bufferSize, 1),
+		failLagging: options.failLagging,
+		branches:    make([]*TeeIterator[T], 0, n),
+		open:        n,
+	}
+	t.cond = sync.NewCond(&t.mx)
+
+	for range n {
+		t.branches = append(t.branches, &TeeIterator[T]{
+			tee:                 t,
+			rowsIteratorOptions: options.rowsIteratorOptions,
+		})
+	}
+
+	return t.branches
+}
+
+func (ti *TeeIterator[T]) Next() bool {
+	var empty teeItem[T]
+	ti.current = empty
+	ti.hasCurrent = false
+
+	t := ti.tee
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	for !ti.closed && !ti.lagging {
+		if idx := ti.pos - t.base; idx < len(t.buffer) {
+			ti.current = t.buffer[idx]
+			ti.hasCurrent = true
+			ti.pos++
+			t.trim()
+
+			return true
+		}
+
+		if t.exhausted {
+			return false
+		}
+
+		if len(t.buffer) < t.bufferSize {
+			t.pull()
+
+			continue
+		}
+
+		// the buffer is full: some branches lag behind
+		if t.failLagging {
+			t.detachLagging()
+
+			continue
+		}
+
+		t.cond.Wait()
+	}
+
+	return false
+}
+
+func (ti *TeeIterator[T]) Item() (T, error) {
+	if !ti.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return ti.current.item, ti.current.err
+}
+
+// Err returns ErrTeeLagging if the branch has been detached, or the error reported by the source iterator.
+func (ti *TeeIterator[T]) Err() error {
+	t := ti.tee
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if ti.lagging {
+		return ErrTeeLagging
+	}
+
+	if t.exhausted && ti.pos-t.base >= len(t.buffer) {
+		return t.err
+	}
+
+	return nil
+}
+
+// Close the branch. The source iterator is closed with the last branch.
+func (ti *TeeIterator[T]) Close() error {
+	t := ti.tee
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if ti.closed {
+		return nil
+	}
+
+	ti.closed = true
+	t.open--
+	t.trim()
+
+	if t.open > 0 {
+		return nil
+	}
+
+	return t.source.Close()
+}
+
+func (ti *TeeIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](ti, ti.preallocatedItems)
+}
+
+func (ti *TeeIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](ti, ti.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (ti *TeeIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](ti)
+}
+
+// pull buffers the next item from the source.
+func (t *tee[T]) pull() {
+	if !t.source.Next() {
+		t.exhausted = true
+		t.err = iteratorErr(t.source)
+		t.cond.Broadcast()
+
+		return
+	}
+
+	item, err := t.source.Item()
+	t.buffer = append(t.buffer, teeItem[T]{item: item, err: err})
+	t.cond.Broadcast()
+}
+
+// trim releases the buffered items already seen by all active branches.
+func (t *tee[T]) trim() {
+	slowest := t.base + len(t.buffer)
+	for _, branch := range t.branches {
+		if branch.closed || branch.lagging {
+			continue
+		}
+
+		slowest = min(slowest, branch.pos)
+	}
+
+	if seen := slowest - t.base; seen > 0 {
+		clear(t.buffer[:seen])
+		t.buffer = t.buffer[seen:]
+		t.base = slowest
+		t.cond.Broadcast()
+	}
+}
+
+// detachLagging detaches the branches waiting for the oldest buffered item.
+func (t *tee[T]) detachLagging() {
+	for _, branch := range t.branches {
+		if !branch.closed && !branch.lagging && branch.pos == t.base {
+			branch.lagging = true
+		}
+	}
+
+	t.trim()
+}
@@ -0,0 +1,129 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeeIterator(t *testing.T) {
+	t.Run("should deliver all items to concurrent branches", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(10)))
+		branches := Tee[int](tracker, 3, 2, WithTeePreallocatedItems(10))
+		require.Len(t, branches, 3)
+
+		results := make([][]int, len(branches))
+		var wg sync.WaitGroup
+		for i, branch := range branches {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				items, err := branch.Collect()
+				require.NoError(t, err)
+				results[i] = items
+			}()
+		}
+		wg.Wait()
+
+		for _, items := range results {
+			require.Equal(t, intSlice(10), items)
+			require.Equal(t, 10, cap(items))
+		}
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should close the source with the last branch", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(10)))
+		branches := Tee[int](tracker, 2, 1)
+
+		require.NoError(t, branches[0].Close())
+		require.False(t, tracker.isClosed())
+		require.NoError(t, branches[0].Close())
+		require.False(t, tracker.isClosed())
+
+		t.Run("closed branches should not hold items", func(t *testing.T) {
+			items, err := branches[1].Collect()
+			require.NoError(t, err)
+			require.Equal(t, intSlice(10), items)
+			require.True(t, tracker.isClosed())
+		})
+	})
+
+	t.Run("should block the fastest branch until the lagging branch catches up", func(t *testing.T) {
+		branches := Tee[int](NewSliceIterator(intSlice(10)), 2, 2)
+		fast, slow := branches[0], branches[1]
+
+		require.True(t, fast.Next())
+		require.True(t, fast.Next())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			require.True(t, fast.Next())
+			item, err := fast.Item()
+			require.NoError(t, err)
+			require.Equal(t, 3, item)
+		}()
+
+		select {
+		case <-done:
+			require.Fail(t, "expected the fastest branch to block")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		require.True(t, slow.Next())
+		<-done
+
+		require.NoError(t, fast.Close())
+		require.NoError(t, slow.Close())
+	})
+
+	t.Run("should detach the lagging branch", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(intSlice(10)))
+		branches := Tee[int](tracker, 2, 2, WithTeeFailLagging())
+		fast, slow := branches[0], branches[1]
+
+		items, err := fast.Collect()
+		require.NoError(t, err)
+		require.Equal(t, intSlice(10), items)
+		require.False(t, tracker.isClosed())
+
+		require.False(t, slow.Next())
+		_, err = slow.Item()
+		require.ErrorIs(t, err, io.EOF)
+		require.ErrorIs(t, slow.Err(), ErrTeeLagging)
+		require.NoError(t, slow.Close())
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should share item errors and terminal errors", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errCursor := errors.New("cursor error")
+		rows := newFakeRows([]string{"id"}, []any{1}, []any{2})
+		rows.err = errCursor
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in == 2 {
+				return 0, errTest
+			}
+
+			return in, nil
+		}
+		source := NewTransformIterator[int, int](context.Background(), NewScanIterator[*fakeRows, int](rows), errorer)
+		branches := Tee[int](source, 2, 10)
+
+		for _, branch := range branches {
+			items, err := CollectWithPolicy[int](branch, SkipErrors())
+			require.Equal(t, []int{1}, items)
+			require.ErrorIs(t, err, errTest)
+			require.ErrorIs(t, err, errCursor)
+		}
+		require.True(t, rows.closed)
+	})
+}
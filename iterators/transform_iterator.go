@@ -2,6 +2,7 @@ package iterators
 
 import (
 	"context"
+	"iter"
 )
 
 const (
@@ -18,21 +19,41 @@ type (
 
 	// TransformIterator transforms any iterator into an iterator that transforms the input of type S into type T
 	// at every call to Item().
+	//
+	// With the WithTransformWorkers option, the transformer runs on a pool of parallel workers instead, and the
+	// input is pulled from the source iterator by a background goroutine.
+	//
+	// In both cases, errors returned by the Item() method of the source iterator or by the transformer
+	// are returned by Item(), and the iteration continues.
+	//
+	// When the context is cancelled, Next() returns false, the source iterator is closed and the context error
+	// is reported by Err().
 	TransformIterator[S, T any] struct {
 		StructIterator[S]
 		iterated    int
 		ctx         context.Context
 		transformer TransformerCtx[S, T]
+		pool        *transformPool[S, T]
+		err         error
+		isClosed    bool
 
 		*rowsIteratorOptions
 	}
 
+	// IteratorContext exposes the current state of an iterator to a transformer or a predicate.
 	IteratorContext struct {
+		// Iterated is the number of items read from the underlying iterator, including the current one.
 		Iterated int
+
+		// Emitted is the number of items delivered by the iterator.
+		//
+		// For a TransformIterator, this is always equal to Iterated.
+		// For a FilterIterator, this is the number of items accepted before the current one.
+		Emitted int
 	}
 )
 
-// GetIteratorContext allows the retrieval of the context of the iterator from within a transformer.
+// GetIteratorContext allows the retrieval of the context of the iterator from within a transformer or a predicate.
 func GetIteratorContext(ctx context.Context) *IteratorContext {
 	val, ok := ctx.Value(ctxKeyIteration).(*IteratorContext)
 	if !ok {
@@ -59,10 +80,46 @@ func NewTransformIterator[S, T any](ctx context.Context, iterator StructIterator
 }
 
 func (rt *TransformIterator[S, T]) iteratorContext() context.Context {
-	return context.WithValue(rt.ctx, ctxKeyIteration, &IteratorContext{Iterated: rt.iterated})
+	return context.WithValue(rt.ctx, ctxKeyIteration, &IteratorContext{Iterated: rt.iterated, Emitted: rt.iterated})
+}
+
+func (rt *TransformIterator[S, T]) iteratedCount() int {
+	if rt.pool != nil {
+		return rt.pool.currentSeq
+	}
+
+	return rt.iterated
 }
 
 func (rt *TransformIterator[S, T]) Next() bool {
+	if rt.err != nil || rt.isClosed {
+		return false
+	}
+
+	if rt.transformWorkers > 0 {
+		if rt.pool == nil {
+			rt.pool = startTransformPool(rt.ctx, rt.StructIterator, rt.transformer, rt.transformWorkers, rt.transformOrdered)
+		}
+
+		if rt.pool.Next() {
+			return true
+		}
+
+		if rt.ctx.Err() != nil {
+			// workers are done: the source iterator may be released
+			rt.closeSource()
+		}
+
+		return false
+	}
+
+	if err := rt.ctx.Err(); err != nil {
+		rt.err = err
+		rt.closeSource()
+
+		return false
+	}
+
 	isNext := rt.StructIterator.Next()
 	if isNext {
 		rt.iterated++
@@ -72,6 +129,10 @@ func (rt *TransformIterator[S, T]) Next() bool {
 }
 
 func (rt *TransformIterator[S, T]) Item() (T, error) {
+	if rt.pool != nil {
+		return rt.pool.Item()
+	}
+
 	input, err := rt.StructIterator.Item()
 	if err != nil {
 		var empty T
@@ -89,6 +150,51 @@ func (rt *TransformIterator[S, T]) Item() (T, error) {
 	return output, nil
 }
 
+// Err returns the error that interrupted the iteration, if any:
+// either the error reported by the source iterator, or the context error if the context is cancelled.
+func (rt *TransformIterator[S, T]) Err() error {
+	if rt.pool != nil {
+		return rt.pool.Err()
+	}
+
+	if rt.err != nil {
+		return rt.err
+	}
+
+	return iteratorErr(rt.StructIterator)
+}
+
+// closeSource closes the source iterator early, upon cancellation.
+func (rt *TransformIterator[S, T]) closeSource() {
+	if rt.isClosed {
+		return
+	}
+
+	rt.isClosed = true
+	_ = rt.StructIterator.Close()
+}
+
+// Close the source iterator.
+//
+// When running parallel workers, all workers are stopped before the source iterator is closed.
+func (rt *TransformIterator[S, T]) Close() error {
+	var poolErr error
+	if rt.pool != nil {
+		poolErr = rt.pool.Close()
+	}
+
+	if rt.isClosed {
+		return poolErr
+	}
+	rt.isClosed = true
+
+	if err := rt.StructIterator.Close(); err != nil {
+		return err
+	}
+
+	return poolErr
+}
+
 func (rt *TransformIterator[S, T]) Collect() ([]T, error) {
 	return collectAndClose[T](rt, rt.preallocatedItems)
 }
@@ -96,3 +202,10 @@ func (rt *TransformIterator[S, T]) Collect() ([]T, error) {
 func (rt *TransformIterator[S, T]) CollectPtr() ([]*T, error) {
 	return collectPtrAndClose[T](rt, rt.preallocatedItems)
 }
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (rt *TransformIterator[S, T]) All() iter.Seq2[T, error] {
+	return Seq[T](rt)
+}
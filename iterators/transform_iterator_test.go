@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -73,6 +75,185 @@ func TestTransformIterator(t *testing.T) {
 		require.ErrorIs(t, err, errTest)
 		require.Len(t, items, 1)
 	})
+
+	t.Run("should stop and close the source on cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(dummySlice()))
+		iterator := NewTransformIterator[dummyStruct, outStruct](ctx, tracker, transformer)
+
+		require.True(t, iterator.Next())
+		cancel()
+		require.False(t, iterator.Next())
+		require.True(t, tracker.isClosed())
+		require.ErrorIs(t, iterator.Err(), context.Canceled)
+
+		_, err := iterator.Collect()
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, int32(1), tracker.closed.Load())
+	})
+}
+
+func TestParallelTransformIterator(t *testing.T) {
+	const size = 200
+	numbers := func() []dummyStruct {
+		slice := make([]dummyStruct, 0, size)
+		for i := 1; i <= size; i++ {
+			slice = append(slice, dummyStruct{A: i})
+		}
+
+		return slice
+	}
+	doubler := func(ctx context.Context, in dummyStruct) (int, error) {
+		ictx := GetIteratorContext(ctx)
+		if ictx == nil || ictx.Iterated != in.A {
+			return 0, errors.New("unexpected iterator context")
+		}
+
+		if in.A%7 == 0 {
+			// make some items slower to transform
+			time.Sleep(time.Millisecond)
+		}
+
+		return 2 * in.A, nil
+	}
+	expected := make([]int, 0, size)
+	for i := 1; i <= size; i++ {
+		expected = append(expected, 2*i)
+	}
+
+	t.Run("should Collect items in source order", func(t *testing.T) {
+		iterator := NewTransformIterator[dummyStruct, int](context.Background(), NewSliceIterator(numbers()), doubler,
+			WithTransformWorkers(8),
+		)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, expected, items)
+	})
+
+	t.Run("should CollectPtr items as completed", func(t *testing.T) {
+		iterator := NewTransformIterator[dummyStruct, int](context.Background(), NewSliceIterator(numbers()), doubler,
+			WithTransformWorkers(8),
+			WithTransformOrdered(false),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		values := make([]int, 0, len(items))
+		for _, item := range items {
+			values = append(values, *item)
+		}
+		require.ElementsMatch(t, expected, values)
+	})
+
+	t.Run("should run transformers in parallel", func(t *testing.T) {
+		const workers = 4
+		var running, maxRunning atomic.Int32
+		latch := make(chan struct{})
+		blocker := func(_ context.Context, in dummyStruct) (int, error) {
+			current := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				previous := maxRunning.Load()
+				if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			if current == workers {
+				close(latch)
+			}
+
+			select {
+			case <-latch:
+			case <-time.After(5 * time.Second):
+			}
+
+			return in.A, nil
+		}
+		iterator := NewTransformIterator[dummyStruct, int](context.Background(), NewSliceIterator(numbers()[:workers]), blocker,
+			WithTransformWorkers(workers),
+		)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Len(t, items, workers)
+		require.Equal(t, int32(workers), maxRunning.Load())
+	})
+
+	t.Run("should return transformer errors with items", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(ctx context.Context, in dummyStruct) (int, error) {
+			if in.A == 50 {
+				return 0, errTest
+			}
+
+			return doubler(ctx, in)
+		}
+		iterator := NewTransformIterator[dummyStruct, int](context.Background(), NewSliceIterator(numbers()), errorer,
+			WithTransformWorkers(4),
+		)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, expected[:49], items)
+
+		t.Run("should skip transformer errors", func(t *testing.T) {
+			iterator := NewTransformIterator[dummyStruct, int](context.Background(), NewSliceIterator(numbers()), errorer,
+				WithTransformWorkers(4),
+			)
+
+			items, err := CollectWithPolicy[int](iterator, SkipErrors())
+			require.ErrorIs(t, err, errTest)
+			require.Len(t, items, size-1)
+			require.NoError(t, iterator.Err())
+		})
+	})
+
+	t.Run("should stop on source failure", func(t *testing.T) {
+		errCursor := errors.New("cursor error")
+		rows := newFakeRows([]string{"a"}, []any{1}, []any{2})
+		rows.err = errCursor
+		iterator := NewTransformIterator[dummyStruct, int](context.Background(), NewScanIterator[*fakeRows, dummyStruct](rows), doubler,
+			WithTransformWorkers(4),
+		)
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errCursor)
+		require.Equal(t, []int{2, 4}, items)
+		require.ErrorIs(t, iterator.Err(), errCursor)
+	})
+
+	t.Run("should stop workers and close source on Close()", func(t *testing.T) {
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(numbers()))
+		iterator := NewTransformIterator[dummyStruct, int](context.Background(), tracker, doubler,
+			WithTransformWorkers(4),
+		)
+
+		require.True(t, iterator.Next())
+		item, err := iterator.Item()
+		require.NoError(t, err)
+		require.Equal(t, 2, item)
+
+		require.NoError(t, iterator.Close())
+		require.True(t, tracker.isClosed())
+	})
+
+	t.Run("should stop on cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		tracker := newCloseTracker[dummyStruct](NewSliceIterator(numbers()))
+		iterator := NewTransformIterator[dummyStruct, int](ctx, tracker, doubler,
+			WithTransformWorkers(4),
+		)
+
+		require.True(t, iterator.Next())
+		cancel()
+
+		_, err := iterator.Collect()
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, iterator.Err(), context.Canceled)
+		require.True(t, tracker.isClosed())
+	})
 }
 
 func TestGetIteratorContext(t *testing.T) {
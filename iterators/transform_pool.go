@@ -0,0 +1,221 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type (
+	// transformPool runs the transformer of a TransformIterator on parallel workers.
+	//
+	// A single goroutine pulls items from the source iterator and dispatches them to the workers.
+	//
+	// The number of items being transformed or waiting to be delivered is bounded by a pool of tokens,
+	// so the reorder buffer used to deliver ordered items is bounded too.
+	//
+	// Errors returned by the Item() method of the source iterator or by the transformer are delivered
+	// with the results, like items.
+	transformPool[S, T any] struct {
+		results    chan transformResult[T]
+		tokens     chan struct{}
+		pending    map[int]transformResult[T]
+		nextSeq    int
+		currentSeq int // the sequence number of the current item in the source iterator
+		ordered    bool
+		current    T
+		itemErr    error
+		sourceErr  error // set by the feeder before the workers are done
+		err        error
+		hasItem    bool
+		done       bool
+		group      *errgroup.Group
+		ctx        context.Context
+		parentCtx  context.Context
+		cancel     context.CancelFunc
+	}
+
+	transformJob[S any] struct {
+		seq   int
+		input S
+		err   error
+	}
+
+	transformResult[T any] struct {
+		seq    int
+		output T
+		err    error
+	}
+)
+
+func startTransformPool[S, T any](parentCtx context.Context, source StructIterator[S], transformer TransformerCtx[S, T], workers int, ordered bool) *transformPool[S, T] {
+	ctx, cancel := context.WithCancel(parentCtx)
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	p := &transformPool[S, T]{
+		results:   make(chan transformResult[T], workers),
+		tokens:    make(chan struct{}, 2*workers),
+		pending:   make(map[int]transformResult[T]),
+		nextSeq:   1,
+		ordered:   ordered,
+		group:     group,
+		ctx:       ctx,
+		parentCtx: parentCtx,
+		cancel:    cancel,
+	}
+	jobs := make(chan transformJob[S], workers)
+
+	group.Go(func() error {
+		defer close(jobs)
+
+		var seq int
+		for source.Next() {
+			input, err := source.Item()
+			if errors.Is(err, io.EOF) {
+				continue
+			}
+			seq++
+
+			select {
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			case p.tokens <- struct{}{}:
+			}
+
+			select {
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			case jobs <- transformJob[S]{seq: seq, input: input, err: err}:
+			}
+		}
+
+		// items pulled before the source failed are still delivered
+		p.sourceErr = iteratorErr(source)
+
+		return nil
+	})
+
+	var pendingWorkers sync.WaitGroup // rendez-vous to close the results channel
+	for i := 0; i < workers; i++ {
+		pendingWorkers.Add(1)
+
+		group.Go(func() error {
+			defer pendingWorkers.Done()
+
+			for job := range jobs {
+				result := transformResult[T]{seq: job.seq, err: job.err}
+				if job.err == nil {
+					iteratorCtx := context.WithValue(groupCtx, ctxKeyIteration, &IteratorContext{Iterated: job.seq, Emitted: job.seq})
+					result.output, result.err = transformer(iteratorCtx, job.input)
+				}
+
+				select {
+				case <-groupCtx.Done():
+					return groupCtx.Err()
+				case p.results <- result:
+				}
+			}
+
+			return nil
+		})
+	}
+
+	group.Go(func() error {
+		pendingWorkers.Wait()
+		close(p.results)
+
+		return nil
+	})
+
+	return p
+}
+
+func (p *transformPool[S, T]) Next() bool {
+	var empty T
+	p.current = empty
+	p.itemErr = nil
+	p.hasItem = false
+
+	if p.done || p.err != nil {
+		p.done = true
+
+		return false
+	}
+
+	for {
+		if p.ordered {
+			if result, ok := p.pending[p.nextSeq]; ok {
+				delete(p.pending, p.nextSeq)
+				p.nextSeq++
+
+				return p.deliver(result)
+			}
+		}
+
+		select {
+		case result, ok := <-p.results:
+			if !ok {
+				// all workers are done: check if they stopped on some error
+				p.err = p.group.Wait()
+				if p.err == nil {
+					p.err = p.sourceErr
+				}
+				p.done = true
+
+				return false
+			}
+
+			if !p.ordered {
+				return p.deliver(result)
+			}
+
+			p.pending[result.seq] = result
+		case <-p.ctx.Done():
+			p.cancel()
+			p.err = preferErrorOverContext(p.ctx.Err(), p.group.Wait())
+
+			return false
+		}
+	}
+}
+
+func (p *transformPool[S, T]) deliver(result transformResult[T]) bool {
+	<-p.tokens
+	p.current = result.output
+	p.currentSeq = result.seq
+	p.itemErr = result.err
+	p.hasItem = true
+
+	return true
+}
+
+func (p *transformPool[S, T]) Item() (T, error) {
+	if !p.hasItem {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return p.current, p.itemErr
+}
+
+// Err returns the error reported by the source iterator, or the context error if the context is cancelled.
+func (p *transformPool[S, T]) Err() error {
+	return p.err
+}
+
+// Close stops all workers and waits for them to complete.
+func (p *transformPool[S, T]) Close() error {
+	p.cancel()
+
+	err := p.group.Wait()
+	if errors.Is(err, context.Canceled) && p.parentCtx.Err() == nil {
+		// workers have been interrupted by Close(), not by the caller's context
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,221 @@
+package iterators
+
+import (
+	"context"
+	"io"
+	"iter"
+)
+
+var (
+	_ StructIterator[dummy] = &TakeWhileIterator[dummy]{}
+	_ StructIterator[dummy] = &DropWhileIterator[dummy]{}
+)
+
+type (
+	// TakeWhileIterator delivers the items from any iterator as long as they are accepted by a predicate.
+	//
+	// The underlying iterator is closed as soon as an item is rejected, e.g. to release a database cursor early.
+	//
+	// Notice that the take-while iterator is not goroutine-safe and should not be iterated concurrently.
+	TakeWhileIterator[T any] struct {
+		releasable[T]
+		whileIterator[T]
+	}
+
+	// DropWhileIterator skips the items from any iterator as long as they are accepted by a predicate,
+	// then delivers the first rejected item and all the remaining items.
+	//
+	// Notice that the drop-while iterator is not goroutine-safe and should not be iterated concurrently.
+	DropWhileIterator[T any] struct {
+		releasable[T]
+		whileIterator[T]
+		dropping bool
+	}
+
+	// whileIterator holds the state shared by TakeWhileIterator and DropWhileIterator.
+	whileIterator[T any] struct {
+		iterated   int
+		emitted    int
+		ctx        context.Context
+		predicate  PredicateCtx[T]
+		current    T
+		err        error
+		hasCurrent bool
+
+		*rowsIteratorOptions
+	}
+)
+
+// TakeWhile makes a StructIterator[T] that stops at the first item rejected by a predicate.
+//
+// The parent context provided allows the predicate to know about the current context of the iterator,
+// using GetIteratorContext.
+//
+// An error returned by the underlying iterator or by the predicate is reported by Item(), and the iteration goes on.
+func TakeWhile[T any](ctx context.Context, iterator StructIterator[T], predicate PredicateCtx[T], opts ...RowsIteratorOption) *TakeWhileIterator[T] {
+	return &TakeWhileIterator[T]{
+		releasable:    releasable[T]{source: iterator},
+		whileIterator: newWhileIterator[T](ctx, predicate, opts),
+	}
+}
+
+// DropWhile makes a StructIterator[T] that skips items until the first item rejected by a predicate.
+//
+// The parent context provided allows the predicate to know about the current context of the iterator,
+// using GetIteratorContext.
+//
+// An error returned by the underlying iterator or by the predicate is reported by Item(), and the iteration goes on.
+func DropWhile[T any](ctx context.Context, iterator StructIterator[T], predicate PredicateCtx[T], opts ...RowsIteratorOption) *DropWhileIterator[T] {
+	return &DropWhileIterator[T]{
+		releasable:    releasable[T]{source: iterator},
+		whileIterator: newWhileIterator[T](ctx, predicate, opts),
+		dropping:      true,
+	}
+}
+
+func newWhileIterator[T any](ctx context.Context, predicate PredicateCtx[T], opts []RowsIteratorOption) whileIterator[T] {
+	return whileIterator[T]{
+		ctx:                 ctx,
+		predicate:           predicate,
+		rowsIteratorOptions: rowsIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (tw *TakeWhileIterator[T]) Next() bool {
+	tw.reset()
+
+	if tw.released || !tw.source.Next() {
+		return false
+	}
+	tw.iterated++
+
+	item, err := tw.source.Item()
+	if isEndOfStream(err) {
+		return false
+	}
+
+	if err != nil {
+		return tw.fail(err)
+	}
+
+	accepted, err := tw.predicate(tw.iteratorContext(), item)
+	if err != nil {
+		return tw.fail(err)
+	}
+
+	if !accepted {
+		tw.release()
+
+		return false
+	}
+
+	return tw.deliver(item)
+}
+
+func (tw *TakeWhileIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](tw, tw.preallocatedItems)
+}
+
+func (tw *TakeWhileIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](tw, tw.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (tw *TakeWhileIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](tw)
+}
+
+func (dw *DropWhileIterator[T]) Next() bool {
+	dw.reset()
+
+	if dw.released {
+		return false
+	}
+
+	for dw.source.Next() {
+		dw.iterated++
+
+		item, err := dw.source.Item()
+		if isEndOfStream(err) {
+			break
+		}
+
+		if err != nil {
+			return dw.fail(err)
+		}
+
+		if !dw.dropping {
+			return dw.deliver(item)
+		}
+
+		accepted, err := dw.predicate(dw.iteratorContext(), item)
+		if err != nil {
+			return dw.fail(err)
+		}
+
+		if !accepted {
+			dw.dropping = false
+
+			return dw.deliver(item)
+		}
+	}
+
+	return false
+}
+
+func (dw *DropWhileIterator[T]) Collect() ([]T, error) {
+	return collectAndClose[T](dw, dw.preallocatedItems)
+}
+
+func (dw *DropWhileIterator[T]) CollectPtr() ([]*T, error) {
+	return collectPtrAndClose[T](dw, dw.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (dw *DropWhileIterator[T]) All() iter.Seq2[T, error] {
+	return Seq[T](dw)
+}
+
+func (wi *whileIterator[T]) iteratorContext() context.Context {
+	return context.WithValue(wi.ctx, ctxKeyIteration, &IteratorContext{Iterated: wi.iterated, Emitted: wi.emitted})
+}
+
+func (wi *whileIterator[T]) iteratedCount() int {
+	return wi.iterated
+}
+
+func (wi *whileIterator[T]) reset() {
+	var empty T
+	wi.current = empty
+	wi.hasCurrent = false
+	wi.err = nil
+}
+
+func (wi *whileIterator[T]) deliver(item T) bool {
+	wi.emitted++
+	wi.current = item
+	wi.hasCurrent = true
+
+	return true
+}
+
+func (wi *whileIterator[T]) fail(err error) bool {
+	wi.err = err
+	wi.hasCurrent = true
+
+	return true
+}
+
+func (wi *whileIterator[T]) Item() (T, error) {
+	if !wi.hasCurrent {
+		var empty T
+
+		return empty, io.EOF
+	}
+
+	return wi.current, wi.err
+}
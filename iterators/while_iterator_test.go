@@ -0,0 +1,130 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeWhileIterator(t *testing.T) {
+	lowerThan3 := func(_ context.Context, in int) (bool, error) {
+		return in < 3, nil
+	}
+
+	t.Run("should stop at the first rejected item", func(t *testing.T) {
+		iterator := TakeWhile[int](context.Background(), NewSliceIterator(append(intSlice(4), 1)), lowerThan3)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("should close the source as soon as an item is rejected", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(append(intSlice(4), 1)))
+		iterator := TakeWhile[int](context.Background(), tracker, lowerThan3)
+
+		require.True(t, iterator.Next())
+		require.True(t, iterator.Next())
+		require.False(t, tracker.isClosed())
+		require.False(t, iterator.Next())
+		require.True(t, tracker.isClosed())
+		require.False(t, iterator.Next())
+
+		require.NoError(t, iterator.Close())
+		require.Equal(t, int32(1), tracker.closed.Load())
+	})
+
+	t.Run("should expose iterated and emitted counts", func(t *testing.T) {
+		var contexts []IteratorContext
+		recorder := func(ctx context.Context, in int) (bool, error) {
+			contexts = append(contexts, *GetIteratorContext(ctx))
+
+			return in < 3, nil
+		}
+		iterator := TakeWhile[int](context.Background(), NewSliceIterator(append(intSlice(4), 1)), recorder)
+
+		_, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Equal(t, []IteratorContext{
+			{Iterated: 1, Emitted: 0},
+			{Iterated: 2, Emitted: 1},
+			{Iterated: 3, Emitted: 2},
+		}, contexts)
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		iterator := TakeWhile[int](context.Background(), newEOFTerminated[int](NewSliceIterator(intSlice(2))), lowerThan3)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, items)
+	})
+
+	t.Run("should report predicate errors", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, _ int) (bool, error) {
+			return false, errTest
+		}
+		iterator := TakeWhile[int](context.Background(), NewSliceIterator(append(intSlice(4), 1)), errorer)
+
+		require.True(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestDropWhileIterator(t *testing.T) {
+	lowerThan3 := func(_ context.Context, in int) (bool, error) {
+		return in < 3, nil
+	}
+
+	t.Run("should deliver items from the first rejected item", func(t *testing.T) {
+		iterator := DropWhile[int](context.Background(), NewSliceIterator(append(intSlice(4), 1)), lowerThan3)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{3, 4, 1}, items)
+	})
+
+	t.Run("should be empty when all items are dropped", func(t *testing.T) {
+		iterator := DropWhile[int](context.Background(), NewSliceIterator([]int{1, 2}), lowerThan3)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Empty(t, items)
+	})
+
+	t.Run("should not evaluate the predicate once an item is rejected", func(t *testing.T) {
+		var calls int
+		counter := func(ctx context.Context, in int) (bool, error) {
+			calls++
+
+			return lowerThan3(ctx, in)
+		}
+		iterator := DropWhile[int](context.Background(), NewSliceIterator(append(intSlice(4), 1)), counter)
+
+		_, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("should end on io.EOF from the source", func(t *testing.T) {
+		iterator := DropWhile[int](context.Background(), newEOFTerminated[int](NewSliceIterator(intSlice(4))), lowerThan3)
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []int{3, 4}, items)
+	})
+
+	t.Run("should close the source", func(t *testing.T) {
+		tracker := newCloseTracker[int](NewSliceIterator(append(intSlice(4), 1)))
+		iterator := DropWhile[int](context.Background(), tracker, lowerThan3)
+
+		require.True(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+		require.True(t, tracker.isClosed())
+		require.False(t, iterator.Next())
+	})
+}
@@ -0,0 +1,158 @@
+package iterators
+
+import (
+	"io"
+	"iter"
+)
+
+var _ StructIterator[Pair[dummy, dummy]] = &ZipIterator[dummy, dummy]{}
+
+type (
+	// Pair holds the items found at the same position in two iterators.
+	//
+	// When zipping to the longest input, the item from an exhausted input is missing and left empty.
+	Pair[A, B any] struct {
+		Left         A
+		Right        B
+		LeftMissing  bool
+		RightMissing bool
+	}
+
+	// ZipIterator pairs the items of two iterators positionally.
+	//
+	// By default, the iteration stops as soon as one of the inputs is exhausted.
+	// With the WithZipLongest() option, the iteration goes on until both inputs are exhausted,
+	// and pairs are flagged with the missing item.
+	//
+	// An error returned by the Item() method of an input is reported by Item(), together with the current pair.
+	// An error that interrupts an input interrupts the iteration, and is reported by Err().
+	//
+	// Notice that the zip iterator is not goroutine-safe and should not be iterated concurrently.
+	ZipIterator[A, B any] struct {
+		left       StructIterator[A]
+		right      StructIterator[B]
+		leftDone   bool
+		rightDone  bool
+		done       bool
+		current    Pair[A, B]
+		itemErr    error
+		err        error
+		hasCurrent bool
+
+		*zipIteratorOptions
+	}
+)
+
+// Zip makes a StructIterator[Pair[A, B]] pairing the items of two iterators positionally.
+//
+// Both inputs are closed when the ZipIterator is closed.
+func Zip[A, B any](left StructIterator[A], right StructIterator[B], opts ...ZipIteratorOption) *ZipIterator[A, B] {
+	return &ZipIterator[A, B]{
+		left:               left,
+		right:              right,
+		zipIteratorOptions: zipIteratorOptionsWithDefault(opts),
+	}
+}
+
+func (z *ZipIterator[A, B]) Next() bool {
+	z.current = Pair[A, B]{}
+	z.itemErr = nil
+	z.hasCurrent = false
+
+	if z.done || z.err != nil {
+		return false
+	}
+
+	hasLeft := !z.leftDone && z.left.Next()
+	if !hasLeft && !z.leftDone {
+		z.leftDone = true
+		if err := iteratorErr(z.left); err != nil {
+			z.err = err
+
+			return false
+		}
+	}
+
+	if !hasLeft && !z.longest {
+		// the shortest input is exhausted: the other input is no longer advanced
+		z.done = true
+
+		return false
+	}
+
+	hasRight := !z.rightDone && z.right.Next()
+	if !hasRight && !z.rightDone {
+		z.rightDone = true
+		if err := iteratorErr(z.right); err != nil {
+			z.err = err
+
+			return false
+		}
+	}
+
+	if (!hasLeft && !hasRight) || (!hasRight && !z.longest) {
+		z.done = true
+
+		return false
+	}
+
+	z.current.LeftMissing = !hasLeft
+	z.current.RightMissing = !hasRight
+
+	var leftErr, rightErr error
+	if hasLeft {
+		z.current.Left, leftErr = z.left.Item()
+	}
+
+	if hasRight {
+		z.current.Right, rightErr = z.right.Item()
+	}
+
+	if leftErr != nil {
+		z.itemErr = leftErr
+	} else {
+		z.itemErr = rightErr
+	}
+	z.hasCurrent = true
+
+	return true
+}
+
+func (z *ZipIterator[A, B]) Item() (Pair[A, B], error) {
+	if !z.hasCurrent {
+		return Pair[A, B]{}, io.EOF
+	}
+
+	return z.current, z.itemErr
+}
+
+// Err returns the error that interrupted one of the inputs, if any.
+func (z *ZipIterator[A, B]) Err() error {
+	return z.err
+}
+
+// Close both inputs.
+func (z *ZipIterator[A, B]) Close() error {
+	leftErr := z.left.Close()
+
+	if err := z.right.Close(); err != nil {
+		return err
+	}
+
+	return leftErr
+}
+
+func (z *ZipIterator[A, B]) Collect() ([]Pair[A, B], error) {
+	return collectAndClose[Pair[A, B]](z, z.preallocatedItems)
+}
+
+func (z *ZipIterator[A, B]) CollectPtr() ([]*Pair[A, B], error) {
+	return collectPtrAndClose[Pair[A, B]](z, z.preallocatedItems)
+}
+
+// All returns a range-over-func sequence over the items of the iterator.
+//
+// The iterator is closed when the loop is done, or interrupted early.
+func (z *ZipIterator[A, B]) All() iter.Seq2[Pair[A, B], error] {
+	return Seq[Pair[A, B]](z)
+}
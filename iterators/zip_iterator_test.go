@@ -0,0 +1,110 @@
+package iterators
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipIterator(t *testing.T) {
+	letters := func() []string {
+		return []string{"a", "b", "c"}
+	}
+	t.Run("should stop at the shortest input", func(t *testing.T) {
+		iterator := Zip[string, int](NewSliceIterator(letters()), NewSliceIterator(intSlice(2)))
+
+		items, err := iterator.Collect()
+		require.NoError(t, err)
+		require.Equal(t, []Pair[string, int]{
+			{Left: "a", Right: 1},
+			{Left: "b", Right: 2},
+		}, items)
+	})
+
+	t.Run("should not advance the other input once the shortest is exhausted", func(t *testing.T) {
+		var pulled int
+		right := FromSeq[int](func(yield func(int) bool) {
+			for _, item := range intSlice(5) {
+				pulled++
+				if !yield(item) {
+					return
+				}
+			}
+		})
+		iterator := Zip[int, int](NewSliceIterator(intSlice(2)), right)
+
+		for range 5 {
+			_ = iterator.Next()
+		}
+		require.False(t, iterator.Next())
+		require.Equal(t, 2, pulled)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("should pad to the longest input", func(t *testing.T) {
+		iterator := Zip[int, string](NewSliceIterator(intSlice(2)), NewSliceIterator(letters()),
+			WithZipLongest(),
+			WithZipPreallocatedItems(10),
+		)
+
+		items, err := iterator.CollectPtr()
+		require.NoError(t, err)
+		require.Equal(t, 10, cap(items))
+		require.Len(t, items, 3)
+		require.Equal(t, Pair[int, string]{Left: 2, Right: "b"}, *items[1])
+		require.Equal(t, Pair[int, string]{Right: "c", LeftMissing: true}, *items[2])
+	})
+
+	t.Run("should be empty with an empty input", func(t *testing.T) {
+		iterator := Zip[string, int](NewSliceIterator(letters()), NewSliceIterator([]int{}))
+
+		require.False(t, iterator.Next())
+		_, err := iterator.Item()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("should close both inputs", func(t *testing.T) {
+		left := newCloseTracker[string](NewSliceIterator(letters()))
+		right := newCloseTracker[int](NewSliceIterator(intSlice(2)))
+		iterator := Zip[string, int](left, right)
+
+		require.True(t, iterator.Next())
+		require.NoError(t, iterator.Close())
+		require.True(t, left.isClosed())
+		require.True(t, right.isClosed())
+	})
+
+	t.Run("should report item errors with the current pair", func(t *testing.T) {
+		errTest := errors.New("test error")
+		errorer := func(_ context.Context, in int) (int, error) {
+			if in == 2 {
+				return 0, errTest
+			}
+
+			return in, nil
+		}
+		right := NewTransformIterator[int, int](context.Background(), NewSliceIterator(intSlice(2)), errorer)
+		iterator := Zip[string, int](NewSliceIterator(letters()), right)
+
+		require.True(t, iterator.Next())
+		require.True(t, iterator.Next())
+		pair, err := iterator.Item()
+		require.ErrorIs(t, err, errTest)
+		require.Equal(t, "b", pair.Left)
+	})
+
+	t.Run("should stop on input error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		rows := newFakeRows([]string{"id"}, []any{1})
+		rows.err = errTest
+		iterator := Zip[string, int](NewSliceIterator(letters()), NewScanIterator[*fakeRows, int](rows), WithZipLongest())
+
+		items, err := iterator.Collect()
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, items, 1)
+		require.ErrorIs(t, iterator.Err(), errTest)
+	})
+}